@@ -0,0 +1,451 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// numeric aggregate error, the item that is not a number flows as FlowableError.Elements
+var ErrNotNumber = errors.New("Not a number!")
+
+var typeInt = reflect.TypeOf(0)
+var typeFloat64 = reflect.TypeOf(0.0)
+
+// aggregator folds the items of a flow, it is created on each connection
+type aggregator interface {
+	// fold an item, stop is true if no more item is needed
+	fold(ctx context.Context, x interface{}) (e error, stop bool)
+	// the result of aggregation, ok is false if nothing is emitted
+	result() (x interface{}, ok bool)
+}
+
+// newAggregateObservable returns an Observable emitting the aggregation of the items when the flow completes.
+// Errors flow to the next Observable unless the user function accepts errors
+func (parent *Observable) newAggregateObservable(name string, newAggregator func(o *Observable) aggregator) (o *Observable) {
+	o = parent.newTransformObservable(name)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			agg := newAggregator(o)
+			for x := range in {
+				if e, ok := x.(error); ok && !o.flip_accept_error {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				e, stop := agg.fold(ctx, x)
+				if e != nil && o.sendToFlow(ctx, e, out) {
+					return
+				}
+				if stop {
+					cancelUpstream(ctx)
+					break
+				}
+			}
+			if x, ok := agg.result(); ok {
+				o.sendToFlow(ctx, x, out)
+			}
+			// drain the inflow, so that the predecessors can complete
+			for range in {
+			}
+		},
+	}
+	return
+}
+
+// call the user function of the Observable, the context is passed in if the function supports it
+func (o *Observable) callFlip(ctx context.Context, params ...reflect.Value) (res []reflect.Value, skip, stop bool, eout error) {
+	if o.flip_sup_ctx {
+		params = append([]reflect.Value{reflect.ValueOf(ctx)}, params...)
+	}
+	return o.userFuncCall(ctx, reflect.ValueOf(o.flip), params)
+}
+
+// check f with `func(acc, x anytype) anytype`
+func (o *Observable) setFoldFunc(f interface{}) {
+	fv := reflect.ValueOf(f)
+	inType := []reflect.Type{typeAny, typeAny}
+	outType := []reflect.Type{typeAny}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b {
+		panic(ErrFuncFlip)
+	}
+	o.flip_accept_error = checkFuncAcceptError(fv)
+	o.flip_sup_ctx = ctx_sup
+	o.flip = fv.Interface()
+}
+
+// foldAggregator applies the fold function of the Observable, the first item is the initial accumulator
+type foldAggregator struct {
+	o   *Observable
+	acc reflect.Value
+	has bool
+}
+
+func (a *foldAggregator) fold(ctx context.Context, x interface{}) (e error, stop bool) {
+	xv := reflect.ValueOf(x)
+	if !a.has {
+		a.acc, a.has = xv, true
+		return
+	}
+	rs, skip, stop, e := a.o.callFlip(ctx, a.acc, xv)
+	if skip || stop || e != nil {
+		return
+	}
+	a.acc = rs[0]
+	return
+}
+
+func (a *foldAggregator) result() (interface{}, bool) {
+	if !a.has {
+		return nil, false
+	}
+	return a.acc.Interface(), true
+}
+
+// Reduce applies the function `func(acc, x anytype) anytype` to the first item and the second item,
+// then feeds the result and the third item to it, and so on. It emits the final result when the Observable completes.
+// Nothing is emitted if the Observable emits no item
+func (parent *Observable) Reduce(f interface{}) (o *Observable) {
+	o = parent.newAggregateObservable("Reduce", func(o *Observable) aggregator {
+		return &foldAggregator{o: o}
+	})
+	o.setFoldFunc(f)
+	return
+}
+
+// Scan applies the function `func(acc, x anytype) anytype` like Reduce, but emits every intermediate result.
+// The first item is emitted as it is
+func (parent *Observable) Scan(f interface{}) (o *Observable) {
+	o = parent.newTransformObservable("Scan")
+	o.setFoldFunc(f)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			agg := &foldAggregator{o: o}
+			end := false
+			for x := range in {
+				if end {
+					continue
+				}
+				if e, ok := x.(error); ok && !o.flip_accept_error {
+					end = o.sendToFlow(ctx, e, out)
+					continue
+				}
+				e, stop := agg.fold(ctx, x)
+				switch {
+				case stop:
+					o.stopFlow(ctx, e, in, out)
+					return
+				case e != nil:
+					end = o.sendToFlow(ctx, e, out)
+				default:
+					acc, _ := agg.result()
+					end = o.sendToFlow(ctx, acc, out)
+				}
+			}
+		},
+	}
+	return
+}
+
+// countAggregator counts items
+type countAggregator struct {
+	count int
+}
+
+func (a *countAggregator) fold(ctx context.Context, x interface{}) (error, bool) {
+	a.count++
+	return nil, false
+}
+
+func (a *countAggregator) result() (interface{}, bool) {
+	return a.count, true
+}
+
+// Count emits the number of items, errors are not counted
+func (parent *Observable) Count() (o *Observable) {
+	return parent.newAggregateObservable("Count", func(o *Observable) aggregator {
+		return &countAggregator{}
+	})
+}
+
+// number kinds
+const (
+	notNumber = iota
+	intNumber
+	uintNumber
+	floatNumber
+)
+
+func numberKind(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intNumber
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintNumber
+	case reflect.Float32, reflect.Float64:
+		return floatNumber
+	}
+	return notNumber
+}
+
+func numberFloat(v reflect.Value) float64 {
+	switch numberKind(v) {
+	case intNumber:
+		return float64(v.Int())
+	case uintNumber:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// compare two numbers, it is -1 if a < b, 0 if a == b, +1 if a > b
+func compareNumbers(a, b reflect.Value) int {
+	var less, greater bool
+	switch ka, kb := numberKind(a), numberKind(b); {
+	case ka == intNumber && kb == intNumber:
+		less, greater = a.Int() < b.Int(), a.Int() > b.Int()
+	case ka == uintNumber && kb == uintNumber:
+		less, greater = a.Uint() < b.Uint(), a.Uint() > b.Uint()
+	default:
+		fa, fb := numberFloat(a), numberFloat(b)
+		less, greater = fa < fb, fa > fb
+	}
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+// sumAggregator sums numbers in the type of the first number, or in float64 once the kinds are mixed
+type sumAggregator struct {
+	sum reflect.Value
+}
+
+func (a *sumAggregator) fold(ctx context.Context, x interface{}) (error, bool) {
+	xv := reflect.ValueOf(x)
+	kind := numberKind(xv)
+	if kind == notNumber {
+		return FlowableError{Err: ErrNotNumber, Elements: x}, false
+	}
+	if !a.sum.IsValid() {
+		a.sum = reflect.New(xv.Type()).Elem()
+	} else if k := numberKind(a.sum); k != kind && k != floatNumber {
+		// an integer sum would truncate floats or wrap negatives
+		sum := reflect.New(typeFloat64).Elem()
+		sum.SetFloat(numberFloat(a.sum))
+		a.sum = sum
+	}
+	switch numberKind(a.sum) {
+	case intNumber:
+		a.sum.SetInt(a.sum.Int() + xv.Int())
+	case uintNumber:
+		a.sum.SetUint(a.sum.Uint() + xv.Uint())
+	default:
+		a.sum.SetFloat(a.sum.Float() + numberFloat(xv))
+	}
+	return nil, false
+}
+
+func (a *sumAggregator) result() (interface{}, bool) {
+	if !a.sum.IsValid() {
+		return 0, true
+	}
+	return a.sum.Interface(), true
+}
+
+// Sum emits the sum of numbers in the type of the first number, or 0 if there is no number.
+// The sum is float64 if signed integers, unsigned integers and floats are mixed.
+// An item which is not a number flows as FlowableError with ErrNotNumber
+func (parent *Observable) Sum() (o *Observable) {
+	return parent.newAggregateObservable("Sum", func(o *Observable) aggregator {
+		return &sumAggregator{}
+	})
+}
+
+// averageAggregator averages numbers as float64
+type averageAggregator struct {
+	sum   float64
+	count int
+}
+
+func (a *averageAggregator) fold(ctx context.Context, x interface{}) (error, bool) {
+	xv := reflect.ValueOf(x)
+	if numberKind(xv) == notNumber {
+		return FlowableError{Err: ErrNotNumber, Elements: x}, false
+	}
+	a.sum += numberFloat(xv)
+	a.count++
+	return nil, false
+}
+
+func (a *averageAggregator) result() (interface{}, bool) {
+	if a.count == 0 {
+		return nil, false
+	}
+	return a.sum / float64(a.count), true
+}
+
+// Average emits the average of numbers as float64. Nothing is emitted if there is no number.
+// An item which is not a number flows as FlowableError with ErrNotNumber
+func (parent *Observable) Average() (o *Observable) {
+	return parent.newAggregateObservable("Average", func(o *Observable) aggregator {
+		return &averageAggregator{}
+	})
+}
+
+// extremeAggregator keeps the min (sign -1) or max (sign +1) item.
+// It compares by the user function of the Observable if any, or compares numbers
+type extremeAggregator struct {
+	o    *Observable
+	sign int
+	x    reflect.Value
+}
+
+func (a *extremeAggregator) fold(ctx context.Context, x interface{}) (e error, stop bool) {
+	xv := reflect.ValueOf(x)
+	if a.o.flip == nil && numberKind(xv) == notNumber {
+		return FlowableError{Err: ErrNotNumber, Elements: x}, false
+	}
+	if !a.x.IsValid() {
+		a.x = xv
+		return
+	}
+	var c int
+	if a.o.flip == nil {
+		c = compareNumbers(xv, a.x)
+	} else {
+		rs, skip, stop, e := a.o.callFlip(ctx, xv, a.x)
+		if skip || stop || e != nil {
+			return e, stop
+		}
+		c = int(rs[0].Int())
+	}
+	if c*a.sign > 0 {
+		a.x = xv
+	}
+	return
+}
+
+func (a *extremeAggregator) result() (interface{}, bool) {
+	if !a.x.IsValid() {
+		return nil, false
+	}
+	return a.x.Interface(), true
+}
+
+// Min emits the smallest number, the first one is emitted if several numbers are the smallest.
+// An item which is not a number flows as FlowableError with ErrNotNumber
+func (parent *Observable) Min() (o *Observable) {
+	return parent.newExtremeObservable("Min", -1, nil)
+}
+
+// Max emits the largest number, the first one is emitted if several numbers are the largest.
+// An item which is not a number flows as FlowableError with ErrNotNumber
+func (parent *Observable) Max() (o *Observable) {
+	return parent.newExtremeObservable("Max", 1, nil)
+}
+
+// MinFunc emits the smallest item compared by `func(a, b anytype) int`, which returns a negative number
+// when a < b, 0 when a == b and a positive number when a > b
+func (parent *Observable) MinFunc(cmp interface{}) (o *Observable) {
+	return parent.newExtremeObservable("MinFunc", -1, cmp)
+}
+
+// MaxFunc emits the largest item compared by `func(a, b anytype) int` as MinFunc
+func (parent *Observable) MaxFunc(cmp interface{}) (o *Observable) {
+	return parent.newExtremeObservable("MaxFunc", 1, cmp)
+}
+
+func (parent *Observable) newExtremeObservable(name string, sign int, cmp interface{}) (o *Observable) {
+	o = parent.newAggregateObservable(name, func(o *Observable) aggregator {
+		return &extremeAggregator{o: o, sign: sign}
+	})
+	if cmp != nil {
+		fv := reflect.ValueOf(cmp)
+		inType := []reflect.Type{typeAny, typeAny}
+		outType := []reflect.Type{typeInt}
+		b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+		if !b {
+			panic(ErrFuncFlip)
+		}
+		o.flip_accept_error = checkFuncAcceptError(fv)
+		o.flip_sup_ctx = ctx_sup
+		o.flip = fv.Interface()
+	}
+	return
+}
+
+// sliceAggregator collects items
+type sliceAggregator struct {
+	items []interface{}
+}
+
+func (a *sliceAggregator) fold(ctx context.Context, x interface{}) (error, bool) {
+	a.items = append(a.items, x)
+	return nil, false
+}
+
+func (a *sliceAggregator) result() (interface{}, bool) {
+	return a.items, true
+}
+
+// ToSlice emits all the items in one `[]interface{}` when the Observable completes.
+// Errors flow before the slice
+func (parent *Observable) ToSlice() (o *Observable) {
+	return parent.newAggregateObservable("ToSlice", func(o *Observable) aggregator {
+		return &sliceAggregator{items: []interface{}{}}
+	})
+}
+
+// mapAggregator collects items by their keys
+type mapAggregator struct {
+	o     *Observable
+	items map[interface{}]interface{}
+}
+
+func (a *mapAggregator) fold(ctx context.Context, x interface{}) (e error, stop bool) {
+	rs, skip, stop, e := a.o.callFlip(ctx, reflect.ValueOf(x))
+	if skip || stop || e != nil {
+		return e, stop
+	}
+	key := rs[0].Interface()
+	if !isMapKey(key) {
+		return FlowableError{Err: ErrKeyNotComparable, Elements: x}, false
+	}
+	a.items[key] = x
+	return
+}
+
+func (a *mapAggregator) result() (interface{}, bool) {
+	return a.items, true
+}
+
+// ToMap emits all the items in one `map[interface{}]interface{}` when the Observable completes,
+// keyed by `func(x anytype) anytype`. A later item replaces the earlier one with the same key.
+// An item whose key can not be a map key flows as FlowableError with ErrKeyNotComparable
+func (parent *Observable) ToMap(keyFunc interface{}) (o *Observable) {
+	fv := reflect.ValueOf(keyFunc)
+	inType := []reflect.Type{typeAny}
+	outType := []reflect.Type{typeAny}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b {
+		panic(ErrFuncFlip)
+	}
+
+	o = parent.newAggregateObservable("ToMap", func(o *Observable) aggregator {
+		return &mapAggregator{o: o, items: make(map[interface{}]interface{})}
+	})
+	o.flip_accept_error = checkFuncAcceptError(fv)
+	o.flip_sup_ctx = ctx_sup
+	o.flip = fv.Interface()
+	return
+}
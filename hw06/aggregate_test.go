@@ -0,0 +1,157 @@
+package rxgo_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// collect all items of o, including errors
+func collect(o *rxgo.Observable) []interface{} {
+	res := []interface{}{}
+	o.Subscribe(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			res = append(res, x)
+		},
+		Error: func(e error) {
+			res = append(res, e)
+		},
+	})
+	return res
+}
+
+func TestReduce(t *testing.T) {
+	res := collect(rxgo.Range(1, 5).Reduce(func(acc, x int) int {
+		return acc * x
+	}))
+	assert.Equal(t, []interface{}{24}, res, "Reduce Test Error!")
+
+	res = collect(rxgo.Empty().Reduce(func(acc, x int) int {
+		return acc + x
+	}))
+	assert.Equal(t, []interface{}{}, res, "Reduce should emit nothing for empty flow")
+
+	// context-aware function
+	res = collect(rxgo.Just("a", "b", "c").Reduce(func(ctx context.Context, acc, x string) string {
+		assert.NotNil(t, ctx)
+		return acc + x
+	}))
+	assert.Equal(t, []interface{}{"abc"}, res, "Reduce Test Error!")
+
+	assert.Panics(t, func() {
+		rxgo.Just(1).Reduce(func(x int) int { return x })
+	}, "Reduce should check the function")
+}
+
+func TestScan(t *testing.T) {
+	res := collect(rxgo.Range(1, 5).Scan(func(acc, x int) int {
+		return acc + x
+	}))
+	assert.Equal(t, []interface{}{1, 3, 6, 10}, res, "Scan Test Error!")
+}
+
+func TestScanStop(t *testing.T) {
+	res := collect(rxgo.Range(1, 5).Scan(func(acc, x int) int {
+		if x == 3 {
+			panic("boom")
+		}
+		return acc + x
+	}).SetPanicPolicy(rxgo.PanicStop))
+	assert.Equal(t, 3, len(res), "Scan Stop Test Error!")
+	assert.Equal(t, []interface{}{1, 3}, res[:2], "Scan Stop Test Error!")
+	assert.True(t, errors.Is(res[2].(error), rxgo.ErrPanic), "Scan Stop Test Error!")
+
+	done := make(chan []interface{})
+	go func() {
+		done <- collect(rxgo.Interval(time.Millisecond).Scan(func(acc, x int) int {
+			if x == 3 {
+				panic(rxgo.ErrEoFlow)
+			}
+			return acc + x
+		}))
+	}()
+	select {
+	case res = <-done:
+		assert.Equal(t, []interface{}{0, 1, 3}, res, "Scan EoFlow Test Error!")
+	case <-time.After(time.Second):
+		t.Fatal("Scan should stop the endless upstream")
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	e := errors.New("any")
+	res := collect(rxgo.Just(1, e, 2).Reduce(func(acc, x int) int {
+		return acc + x
+	}))
+	assert.Equal(t, []interface{}{e, 3}, res, "errors should flow through Reduce")
+
+	res = collect(rxgo.Just(1, e, 2).Count())
+	assert.Equal(t, []interface{}{e, 2}, res, "errors should not be counted")
+}
+
+func TestCount(t *testing.T) {
+	assert.Equal(t, []interface{}{4}, collect(rxgo.Range(0, 4).Count()), "Count Test Error!")
+	assert.Equal(t, []interface{}{0}, collect(rxgo.Empty().Count()), "Count Test Error!")
+}
+
+func TestSumAverage(t *testing.T) {
+	assert.Equal(t, []interface{}{10}, collect(rxgo.Range(0, 5).Sum()), "Sum Test Error!")
+	assert.Equal(t, []interface{}{4.0}, collect(rxgo.Just(1.5, 2.5).Sum()), "Sum Test Error!")
+	assert.Equal(t, []interface{}{uint8(7)}, collect(rxgo.Just(uint8(3), uint8(4)).Sum()), "Sum Test Error!")
+	assert.Equal(t, []interface{}{0}, collect(rxgo.Empty().Sum()), "Sum Test Error!")
+	assert.Equal(t, []interface{}{2.5}, collect(rxgo.Range(1, 5).Average()), "Average Test Error!")
+	assert.Equal(t, []interface{}{}, collect(rxgo.Empty().Average()), "Average Test Error!")
+
+	res := collect(rxgo.Just(1, "x", 2).Sum())
+	assert.Equal(t, 2, len(res), "Sum Test Error!")
+	assert.Equal(t, rxgo.FlowableError{Err: rxgo.ErrNotNumber, Elements: "x"}, res[0], "Sum should report a non-number")
+	assert.Equal(t, 3, res[1], "Sum Test Error!")
+}
+
+func TestSumMixed(t *testing.T) {
+	assert.Equal(t, []interface{}{4.0}, collect(rxgo.Just(1, 1.5, 1.5).Sum()), "Sum should promote to float64")
+	assert.Equal(t, []interface{}{-1.0}, collect(rxgo.Just(uint(1), -2).Sum()), "Sum should not wrap negatives")
+	assert.Equal(t, []interface{}{3.5}, collect(rxgo.Just(uint8(1), 2, 0.5).Sum()), "Sum Test Error!")
+	assert.Equal(t, []interface{}{float32(2.5)}, collect(rxgo.Just(float32(1.5), 1).Sum()), "Sum Test Error!")
+	assert.Equal(t, []interface{}{-3}, collect(rxgo.Just(-1, -2).Sum()), "Sum Test Error!")
+}
+
+func TestMinMax(t *testing.T) {
+	assert.Equal(t, []interface{}{-2}, collect(rxgo.Just(3, -2, 7, 0).Min()), "Min Test Error!")
+	assert.Equal(t, []interface{}{7}, collect(rxgo.Just(3, -2, 7, 0).Max()), "Max Test Error!")
+	assert.Equal(t, []interface{}{0.5}, collect(rxgo.Just(1, 0.5, 2).Min()), "Min Test Error!")
+	assert.Equal(t, []interface{}{}, collect(rxgo.Empty().Max()), "Max Test Error!")
+
+	byLen := func(a, b string) int {
+		return len(a) - len(b)
+	}
+	words := rxgo.Just("ccc", "a", "bb", "d", "eee")
+	assert.Equal(t, []interface{}{"a"}, collect(words.MinFunc(byLen)), "MinFunc Test Error!")
+	words = rxgo.Just("ccc", "a", "bb", "d", "eee")
+	assert.Equal(t, []interface{}{"ccc"}, collect(words.MaxFunc(byLen)), "MaxFunc Test Error!")
+}
+
+func TestToSliceToMap(t *testing.T) {
+	assert.Equal(t, []interface{}{[]interface{}{1, 2, 3}}, collect(rxgo.Just(1, 2, 3).ToSlice()), "ToSlice Test Error!")
+	assert.Equal(t, []interface{}{[]interface{}{}}, collect(rxgo.Empty().ToSlice()), "ToSlice Test Error!")
+
+	res := collect(rxgo.Just("apple", "avocado", "banana").ToMap(func(s string) string {
+		return strings.ToUpper(s[:1])
+	}))
+	want := map[interface{}]interface{}{"A": "avocado", "B": "banana"}
+	assert.Equal(t, []interface{}{want}, res, "ToMap Test Error!")
+
+	res = collect(rxgo.Just(1, 2).ToMap(func(x int) interface{} {
+		if x == 1 {
+			return []int{x}
+		}
+		return x
+	}))
+	want = map[interface{}]interface{}{2: 2}
+	assert.Equal(t, []interface{}{rxgo.FlowableError{Err: rxgo.ErrKeyNotComparable, Elements: 1}, want}, res, "ToMap Test Error!")
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// BackpressureStrategy decides what an Observable does when its outflow is full,
+// that is the downstream can not keep up with it. Errors are never dropped, they wait for room as Block does
+type BackpressureStrategy uint
+
+const (
+	BackpressureBlock      BackpressureStrategy = iota // wait until the outflow has room
+	BackpressureDropNewest                             // drop the item that can not be sent
+	BackpressureDropOldest                             // drop the oldest item in the outflow to make room
+	BackpressureKeepLatest                             // drop all items in the outflow and keep only the latest one
+	BackpressureError                                  // emit ErrBufferOverflow and stop the flow
+)
+
+// BackpressureMonitor is an Observer that is notified of the items dropped by backpressure.
+// Set it by SetMonitor, ObserverMonitor implements it with the Dropped function
+type BackpressureMonitor interface {
+	Observer
+	OnDropped(x interface{}, count uint64)
+}
+
+// SetBackpressure set the strategy used when the outflow of the Observable is full.
+// An Observable without buffer, such as a source, can not keep items in its outflow,
+// so the DropOldest and KeepLatest strategies act as DropNewest there
+func (o *Observable) SetBackpressure(s BackpressureStrategy) *Observable {
+	o.backpressure = s
+	return o
+}
+
+// Dropped returns the number of items dropped by backpressure, counted over all the subscriptions of the Observable
+func (o *Observable) Dropped() uint64 {
+	return atomic.LoadUint64(&o.dropped)
+}
+
+func (o *Observable) sendWithBackpressure(ctx context.Context, item interface{}, out chan interface{}) (end bool) {
+	for {
+		select {
+		case out <- item:
+			o.monitorItem(item, out)
+			return
+		case <-ctx.Done():
+			return true
+		default:
+		}
+
+		// the outflow is full, and the downstream may take items at the same time,
+		// so try to send again after making room
+		if isError(item) && o.backpressure != BackpressureError {
+			return o.sendBlocking(ctx, item, out)
+		}
+		switch o.backpressure {
+		case BackpressureDropOldest, BackpressureKeepLatest:
+			if cap(out) == 0 {
+				o.dropItem(item)
+				return
+			}
+			if !o.dropQueued(ctx, out, o.backpressure == BackpressureKeepLatest) {
+				// the outflow is full of errors
+				return o.sendBlocking(ctx, item, out)
+			}
+		case BackpressureError:
+			// the flow stops here, so stop the Observables before it too
+			cancelUpstream(ctx)
+			o.dropItem(item)
+			e := FlowableError{Err: ErrBufferOverflow, Elements: item}
+			select {
+			case out <- e:
+				o.monitorItem(e, out)
+			case <-ctx.Done():
+			}
+			return true
+		default:
+			o.dropItem(item)
+			return
+		}
+	}
+}
+
+// wait until the outflow has room as BackpressureBlock does
+func (o *Observable) sendBlocking(ctx context.Context, item interface{}, out chan interface{}) (end bool) {
+	select {
+	case out <- item:
+		o.monitorItem(item, out)
+	case <-ctx.Done():
+		end = true
+	}
+	return
+}
+
+// dropQueued drops the oldest item in the outflow, or all items if all is true, but never drops errors.
+// The errors and the items after them are taken out and put back in order, it returns false if nothing is dropped
+func (o *Observable) dropQueued(ctx context.Context, out chan interface{}, all bool) (dropped bool) {
+	var kept []interface{}
+	for taken := true; taken; {
+		select {
+		case x := <-out:
+			if isError(x) || (dropped && !all) {
+				kept = append(kept, x)
+				continue
+			}
+			o.dropItem(x)
+			dropped = true
+			// the oldest one is not an error, nothing to put back
+			taken = all || len(kept) > 0
+		default:
+			taken = false
+		}
+	}
+	for _, x := range kept {
+		select {
+		case out <- x:
+		case <-ctx.Done():
+			return
+		}
+	}
+	return
+}
+
+func (o *Observable) dropItem(x interface{}) {
+	count := atomic.AddUint64(&o.dropped, 1)
+	if m, ok := o.debug.(BackpressureMonitor); ok {
+		m.OnDropped(x, count)
+	}
+}
@@ -0,0 +1,162 @@
+package rxgo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// feed 0..9 to an operator with 4 buffers, while the subscriber is blocked on the first item
+func runOverflow(s rxgo.BackpressureStrategy) (res []interface{}, dropped []interface{}, count uint64) {
+	items := []interface{}{}
+	for i := 0; i < 10; i++ {
+		items = append(items, i)
+	}
+	return runOverflowItems(s, items)
+}
+
+// feed items to an operator with 4 buffers, while the subscriber is blocked on the first item 0
+func runOverflowItems(s rxgo.BackpressureStrategy, items []interface{}) (res []interface{}, dropped []interface{}, count uint64) {
+	ch := make(chan interface{})
+	received, gate, produced := make(chan struct{}), make(chan struct{}), make(chan struct{})
+
+	ob := rxgo.From(ch).Map(func(x int) int {
+		return x
+	}).SetBufferLen(4).SetBackpressure(s)
+	ob.SetMonitor(rxgo.ObserverMonitor{
+		Dropped: func(x interface{}, n uint64) {
+			dropped = append(dropped, x)
+			// the operator is going to block on emitting the overflow error
+			if s == rxgo.BackpressureError {
+				close(gate)
+			}
+		},
+		Completed: func() {
+			close(produced)
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ob.Subscribe(rxgo.ObserverMonitor{
+			Next: func(x interface{}) {
+				res = append(res, x)
+				if x == 0 {
+					close(received)
+					<-gate
+				}
+			},
+			Error: func(e error) {
+				res = append(res, e)
+			},
+		})
+		close(done)
+	}()
+
+	ch <- items[0]
+	<-received
+	for _, x := range items[1:] {
+		select {
+		case ch <- x:
+		case <-done: // BackpressureError stops the source
+		}
+	}
+	close(ch)
+	if s != rxgo.BackpressureError {
+		<-produced
+		close(gate)
+	}
+	<-done
+	return res, dropped, ob.Dropped()
+}
+
+func TestBackpressureKeepErrors(t *testing.T) {
+	e := errors.New("oops")
+	items := []interface{}{0, 1, e, 2, 3, 4, 5, 6, 7, 8, 9}
+	for _, s := range []rxgo.BackpressureStrategy{rxgo.BackpressureDropNewest, rxgo.BackpressureDropOldest, rxgo.BackpressureKeepLatest} {
+		res, dropped, count := runOverflowItems(s, items)
+		assert.Contains(t, res, e, "Backpressure should never drop errors")
+		assert.NotContains(t, dropped, e, "Backpressure should never drop errors")
+		assert.Equal(t, len(items), len(res)+int(count), "Backpressure lost items")
+		last := -1
+		for _, x := range res {
+			if n, ok := x.(int); ok {
+				assert.True(t, n > last, "Backpressure should keep the order")
+				last = n
+			}
+		}
+	}
+}
+
+func TestBackpressureDropNewest(t *testing.T) {
+	res, dropped, count := runOverflow(rxgo.BackpressureDropNewest)
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, res, "DropNewest Test Error!")
+	assert.Equal(t, []interface{}{5, 6, 7, 8, 9}, dropped, "DropNewest dropped items Error!")
+	assert.Equal(t, uint64(5), count, "DropNewest count Error!")
+}
+
+func TestBackpressureDropOldest(t *testing.T) {
+	res, dropped, count := runOverflow(rxgo.BackpressureDropOldest)
+	assert.Equal(t, []interface{}{0, 6, 7, 8, 9}, res, "DropOldest Test Error!")
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, dropped, "DropOldest dropped items Error!")
+	assert.Equal(t, uint64(5), count, "DropOldest count Error!")
+}
+
+func TestBackpressureKeepLatest(t *testing.T) {
+	res, dropped, count := runOverflow(rxgo.BackpressureKeepLatest)
+	assert.Equal(t, []interface{}{0, 9}, res, "KeepLatest Test Error!")
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6, 7, 8}, dropped, "KeepLatest dropped items Error!")
+	assert.Equal(t, uint64(8), count, "KeepLatest count Error!")
+}
+
+func TestBackpressureError(t *testing.T) {
+	res, dropped, count := runOverflow(rxgo.BackpressureError)
+	assert.Equal(t, 6, len(res), "Error Test Error!")
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, res[:5], "Error Test Error!")
+	e, ok := res[5].(rxgo.FlowableError)
+	assert.True(t, ok, "Error should be FlowableError")
+	assert.Equal(t, rxgo.ErrBufferOverflow, e.Err, "Error Test Error!")
+	assert.Equal(t, 5, e.Elements, "Error should carry the item")
+	assert.Equal(t, []interface{}{5}, dropped, "Error dropped items Error!")
+	assert.Equal(t, uint64(1), count, "Error count Error!")
+}
+
+func TestBackpressureErrorStopsUpstream(t *testing.T) {
+	stopped, gate := make(chan struct{}), make(chan struct{})
+	ob := rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		defer close(stopped)
+		for i := 0; !send(i); i++ {
+		}
+	}).Map(func(x int) int {
+		return x
+	}).SetBufferLen(1).SetBackpressure(rxgo.BackpressureError)
+	ob.SetMonitor(rxgo.ObserverMonitor{
+		Dropped: func(x interface{}, n uint64) {
+			close(gate)
+		},
+	})
+	ob.Subscribe(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			if x == 0 {
+				<-gate
+			}
+		},
+	})
+	<-stopped
+	assert.Equal(t, uint64(1), ob.Dropped(), "Error should stop the source")
+}
+
+func TestBackpressureBlock(t *testing.T) {
+	res := []int{}
+	ob := rxgo.Range(0, 1000).Map(func(x int) int {
+		return x
+	}).SetBufferLen(1)
+	ob.Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, 1000, len(res), "Block should not drop items")
+	assert.Equal(t, uint64(0), ob.Dropped(), "Block should not drop items")
+}
@@ -0,0 +1,229 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Buffer emits items in `[]interface{}` of count items, the last buffer may have fewer items.
+// Errors are not buffered, they flow at once
+func (parent *Observable) Buffer(count int) (o *Observable) {
+	o = parent.BufferWithTimeOrCount(0, count)
+	o.Name = "Buffer"
+	return
+}
+
+// BufferWithTime emits items in `[]interface{}`, a buffer is emitted when the timespan has passed since its first item.
+// No empty buffer is emitted. Errors are not buffered, they flow at once
+func (parent *Observable) BufferWithTime(timespan time.Duration) (o *Observable) {
+	o = parent.BufferWithTimeOrCount(timespan, 0)
+	o.Name = "BufferWithTime"
+	return
+}
+
+// BufferWithTimeOrCount emits items in `[]interface{}`, a buffer is emitted when it has count items,
+// or the timespan has passed since its first item, whichever comes first. A zero timespan or count means no limit.
+// No empty buffer is emitted. Errors are not buffered, they flow at once
+func (parent *Observable) BufferWithTimeOrCount(timespan time.Duration, count int) (o *Observable) {
+	o = parent.newTransformObservable("BufferWithTimeOrCount")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			var buf []interface{}
+			var timer SchedulerTimer
+			var fire <-chan time.Time
+			stopTimer := func() {
+				if timer != nil {
+					timer.Stop()
+					timer, fire = nil, nil
+				}
+			}
+			defer stopTimer()
+			flush := func() (end bool) {
+				stopTimer()
+				if len(buf) == 0 {
+					return
+				}
+				end = o.sendToFlow(ctx, buf, out)
+				buf = nil
+				return
+			}
+
+			for {
+				select {
+				case x, ok := <-in:
+					if !ok {
+						flush()
+						return
+					}
+					if e, ok := x.(error); ok {
+						if o.sendToFlow(ctx, e, out) {
+							return
+						}
+						continue
+					}
+					buf = append(buf, x)
+					if len(buf) == 1 && timespan > 0 {
+						timer = o.getScheduler().NewTimer(timespan)
+						fire = timer.C()
+					}
+					if count > 0 && len(buf) >= count && flush() {
+						return
+					}
+				case <-fire:
+					if flush() {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		},
+	}
+	return
+}
+
+// Window emits Observables, each of which emits count items, the last window may have fewer items.
+// Items of a window are kept until it is first subscribed and replayed only to its first subscriber,
+// so that a window subscribed late misses nothing. The later subscribers get only the items not yet emitted.
+// Errors are not windowed, they flow at once
+func (parent *Observable) Window(count int) (o *Observable) {
+	o = parent.newTransformObservable("Window")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			var window *Subject
+			n := 0
+			defer func() {
+				if window != nil {
+					window.OnCompleted()
+				}
+			}()
+
+			for x := range in {
+				if e, ok := x.(error); ok {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				if window == nil {
+					window, n = newGroupSubject(), 0
+					if o.sendToFlow(ctx, window.Observable, out) {
+						return
+					}
+				}
+				window.OnNext(x)
+				if n++; count > 0 && n >= count {
+					window.OnCompleted()
+					window = nil
+				}
+			}
+		},
+	}
+	return
+}
+
+// GroupedObservable is an Observable emitting the items with the same key
+type GroupedObservable struct {
+	*Observable
+	Key interface{}
+}
+
+// GroupBy divides items into groups by the key returned from `func(x anytype) anytype`, and emits
+// a GroupedObservable for each key when its first item arrives. All groups complete when the Observable completes.
+// Items of a group are kept until it is first subscribed and replayed only to its first subscriber,
+// so that a group subscribed late misses nothing. The later subscribers get only the items not yet emitted.
+// Errors are not grouped, they flow at once. An item whose key can not be a map key flows as FlowableError
+// with ErrKeyNotComparable
+func (parent *Observable) GroupBy(keyFunc interface{}) (o *Observable) {
+	fv := reflect.ValueOf(keyFunc)
+	inType := []reflect.Type{typeAny}
+	outType := []reflect.Type{typeAny}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b {
+		panic(ErrFuncFlip)
+	}
+
+	o = parent.newTransformObservable("GroupBy")
+	o.flip_sup_ctx = ctx_sup
+	o.flip = fv.Interface()
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			groups := make(map[interface{}]*Subject)
+			defer func() {
+				for _, g := range groups {
+					g.OnCompleted()
+				}
+			}()
+
+			for x := range in {
+				if e, ok := x.(error); ok {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				rs, skip, stop, e := o.callFlip(ctx, reflect.ValueOf(x))
+				if stop {
+					o.stopFlow(ctx, e, in, out)
+					return
+				}
+				if skip {
+					continue
+				}
+				if e != nil {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				key := rs[0].Interface()
+				if !isMapKey(key) {
+					if o.sendToFlow(ctx, FlowableError{Err: ErrKeyNotComparable, Elements: x}, out) {
+						return
+					}
+					continue
+				}
+				g, ok := groups[key]
+				if !ok {
+					g = newGroupSubject()
+					groups[key] = g
+					if o.sendToFlow(ctx, GroupedObservable{g.Observable, key}, out) {
+						return
+					}
+				}
+				g.OnNext(x)
+			}
+		},
+	}
+	return
+}
+
+// Pairwise emits each item with its previous one in `[]interface{}{previous, current}`,
+// so nothing is emitted for the first item. Errors flow at once
+func (parent *Observable) Pairwise() (o *Observable) {
+	o = parent.newTransformObservable("Pairwise")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			var prev interface{}
+			has := false
+			for x := range in {
+				if e, ok := x.(error); ok {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				if has && o.sendToFlow(ctx, []interface{}{prev, x}, out) {
+					return
+				}
+				prev, has = x, true
+			}
+		},
+	}
+	return
+}
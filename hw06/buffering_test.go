@@ -0,0 +1,131 @@
+package rxgo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer(t *testing.T) {
+	e := errors.New("any")
+	res := collect(rxgo.Just(1, 2, e, 3, 4, 5).Buffer(2))
+	want := []interface{}{[]interface{}{1, 2}, e, []interface{}{3, 4}, []interface{}{5}}
+	assert.Equal(t, want, res, "Buffer Test Error!")
+	assert.Equal(t, []interface{}{}, collect(rxgo.Empty().Buffer(2)), "Buffer should emit no empty buffer")
+}
+
+func TestBufferWithTimeOrCount(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	res := make(chan interface{}, 10)
+	done := make(chan struct{})
+	go func() {
+		rxgo.From(ch).BufferWithTimeOrCount(20*time.Millisecond, 3).SetScheduler(s).Subscribe(func(x []interface{}) {
+			res <- x
+		})
+		close(done)
+	}()
+
+	ch <- 1
+	ch <- 2
+	ch <- 3 // full
+	assert.Equal(t, []interface{}{1, 2, 3}, <-res, "BufferWithTimeOrCount Test Error!")
+	ch <- 4
+	s.Advance(10 * time.Millisecond)
+	ch <- 5
+	s.Advance(10 * time.Millisecond) // 20ms since 4
+	assert.Equal(t, []interface{}{4, 5}, <-res, "BufferWithTimeOrCount Test Error!")
+	s.Advance(50 * time.Millisecond) // no empty buffer
+	ch <- 6
+	close(ch)
+	<-done
+	assert.Equal(t, []interface{}{6}, <-res, "BufferWithTimeOrCount Test Error!")
+	assert.Equal(t, 0, len(res), "BufferWithTimeOrCount Test Error!")
+	assert.Equal(t, 0, s.Pending(), "BufferWithTimeOrCount timer not stopped")
+}
+
+func TestBufferWithTime(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	res := make(chan interface{}, 10)
+	done := make(chan struct{})
+	go func() {
+		rxgo.From(ch).BufferWithTime(time.Second).SetScheduler(s).Subscribe(func(x []interface{}) {
+			res <- x
+		})
+		close(done)
+	}()
+
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	s.Advance(time.Second)
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, <-res, "BufferWithTime Test Error!")
+	close(ch)
+	<-done
+	assert.Equal(t, 0, len(res), "BufferWithTime Test Error!")
+}
+
+func TestWindow(t *testing.T) {
+	windows := [][]int{}
+	rxgo.Range(0, 5).Window(2).Subscribe(func(w *rxgo.Observable) {
+		items := []int{}
+		w.Subscribe(func(x int) {
+			items = append(items, x)
+		})
+		windows = append(windows, items)
+	})
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4}}, windows, "Window Test Error!")
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := []rxgo.GroupedObservable{}
+	rxgo.Range(0, 7).GroupBy(func(x int) bool {
+		return x%2 == 0
+	}).Subscribe(func(g rxgo.GroupedObservable) {
+		groups = append(groups, g)
+	})
+
+	// groups subscribed after completion get all their items
+	assert.Equal(t, 2, len(groups), "GroupBy Test Error!")
+	res := map[interface{}][]int{}
+	for _, g := range groups {
+		key := g.Key
+		g.Subscribe(func(x int) {
+			res[key] = append(res[key], x)
+		})
+	}
+	assert.Equal(t, map[interface{}][]int{true: {0, 2, 4, 6}, false: {1, 3, 5}}, res, "GroupBy Test Error!")
+}
+
+func TestGroupByReplayOnce(t *testing.T) {
+	groups := []interface{}{}
+	rxgo.Just(1, 2, 3).GroupBy(func(x int) interface{} {
+		if x == 2 {
+			return []int{x}
+		}
+		return x % 2
+	}).Subscribe(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			groups = append(groups, x)
+		},
+		Error: func(e error) {
+			groups = append(groups, e)
+		},
+	})
+
+	assert.Equal(t, 2, len(groups), "GroupBy Test Error!")
+	assert.Equal(t, rxgo.FlowableError{Err: rxgo.ErrKeyNotComparable, Elements: 2}, groups[1], "GroupBy should report an unhashable key")
+	g := groups[0].(rxgo.GroupedObservable)
+	assert.Equal(t, []interface{}{1, 3}, collect(g.Observable), "the first subscriber should get all items")
+	assert.Equal(t, []interface{}{}, collect(g.Observable), "the later subscribers should get no replayed item")
+}
+
+func TestPairwise(t *testing.T) {
+	res := collect(rxgo.Just(1, 2, 3).Pairwise())
+	assert.Equal(t, []interface{}{[]interface{}{1, 2}, []interface{}{2, 3}}, res, "Pairwise Test Error!")
+	assert.Equal(t, []interface{}{}, collect(rxgo.Just(1).Pairwise()), "Pairwise Test Error!")
+}
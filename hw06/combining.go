@@ -0,0 +1,248 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+var mergeSource = rangeSource
+var concatSource = rangeSource
+var zipSource = rangeSource
+var combineLatestSource = rangeSource
+
+// an item received from the i-th Observable of a combination
+type indexedItem struct {
+	index int
+	item  interface{}
+}
+
+// Merge combines multiple Observables into one by merging their emissions.
+// It completes when all the Observables complete
+func Merge(obs ...*Observable) *Observable {
+	o := newGeneratorObservable("Merge")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		for _, ob := range obs {
+			ch := ob.connectFlow(ctx)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for item := range ch {
+					if b := o.sendToFlow(ctx, item, out); b {
+						cancel()
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	o.operator = mergeSource
+	return o
+}
+
+// Concat emits the emissions from two or more Observables without interleaving them.
+// The next Observable is subscribed only when the previous one completes
+func Concat(obs ...*Observable) *Observable {
+	o := newGeneratorObservable("Concat")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		for _, ob := range obs {
+			if b := o.forwardFlow(ctx, ob, out); b {
+				return
+			}
+		}
+	}
+	o.operator = concatSource
+	return o
+}
+
+// Zip combines the emissions of multiple Observables together via the function
+// `func(x1, x2, ... anytype) anytype` and emits single items for each combination in strict sequence.
+// It completes as soon as any of the Observables completes
+func Zip(f interface{}, obs ...*Observable) *Observable {
+	fv, ctx_sup := checkCombineFunc(f, len(obs))
+
+	o := newGeneratorObservable("Zip")
+	o.flip_sup_ctx = ctx_sup
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		chs := make([]chan interface{}, len(obs))
+		for i, ob := range obs {
+			chs[i] = ob.connectFlow(ctx)
+		}
+
+		for {
+			params := o.combineParams(ctx)
+			for _, ch := range chs {
+				for got := false; !got; {
+					select {
+					case x, ok := <-ch:
+						if !ok {
+							return
+						}
+						// errors are not zipped, they flow to subscriber directly
+						if e, ok := x.(error); ok {
+							if b := o.sendToFlow(ctx, e, out); b {
+								return
+							}
+							continue
+						}
+						params = append(params, reflect.ValueOf(x))
+						got = true
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			if b := o.sendCombination(ctx, fv, params, out); b {
+				return
+			}
+		}
+	}
+	o.operator = zipSource
+	return o
+}
+
+// CombineLatest combines the latest item emitted by each Observable via the function
+// `func(x1, x2, ... anytype) anytype` whenever any of them emits an item,
+// once all the Observables have emitted at least one item.
+// It completes when all the Observables complete
+func CombineLatest(f interface{}, obs ...*Observable) *Observable {
+	fv, ctx_sup := checkCombineFunc(f, len(obs))
+
+	o := newGeneratorObservable("CombineLatest")
+	o.flip_sup_ctx = ctx_sup
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		in := make(chan indexedItem)
+		done := make(chan int)
+		for i, ob := range obs {
+			i, ch := i, ob.connectFlow(ctx)
+			go func() {
+				for item := range ch {
+					select {
+					case in <- indexedItem{i, item}:
+					case <-ctx.Done():
+						return
+					}
+				}
+				select {
+				case done <- i:
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		latest := make([]interface{}, len(obs))
+		seen := make([]bool, len(obs))
+		nseen, ndone := 0, 0
+		for ndone < len(obs) {
+			select {
+			case x := <-in:
+				if e, ok := x.item.(error); ok {
+					if b := o.sendToFlow(ctx, e, out); b {
+						return
+					}
+					continue
+				}
+				if !seen[x.index] {
+					seen[x.index] = true
+					nseen++
+				}
+				latest[x.index] = x.item
+				if nseen < len(obs) {
+					continue
+				}
+				params := o.combineParams(ctx)
+				for _, item := range latest {
+					params = append(params, reflect.ValueOf(item))
+				}
+				if b := o.sendCombination(ctx, fv, params, out); b {
+					return
+				}
+			case i := <-done:
+				// no combination can be made if an Observable completes without any item
+				if !seen[i] {
+					return
+				}
+				ndone++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	o.operator = combineLatestSource
+	return o
+}
+
+// subscribe the Observable and send all its items to out until it completes
+func (o *Observable) forwardFlow(ctx context.Context, ob *Observable, out chan interface{}) (end bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for item := range ob.connectFlow(ctx) {
+		if end = o.sendToFlow(ctx, item, out); end {
+			return
+		}
+	}
+	return
+}
+
+// check the combine function `func(x1, x2, ... anytype) anytype` with n inputs
+func checkCombineFunc(f interface{}, n int) (fv reflect.Value, ctx_sup bool) {
+	fv = reflect.ValueOf(f)
+	inType := make([]reflect.Type, n)
+	for i := range inType {
+		inType[i] = typeAny
+	}
+	outType := []reflect.Type{typeAny}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b || n == 0 {
+		panic(ErrFuncFlip)
+	}
+	return
+}
+
+func (o *Observable) combineParams(ctx context.Context) []reflect.Value {
+	if o.flip_sup_ctx {
+		return []reflect.Value{reflect.ValueOf(ctx)}
+	}
+	return []reflect.Value{}
+}
+
+// call the combine function and send the result
+func (o *Observable) sendCombination(ctx context.Context, fv reflect.Value, params []reflect.Value, out chan interface{}) (end bool) {
+	rs, skip, stop, e := o.userFuncCall(ctx, fv, params)
+	if stop {
+		if e != nil {
+			o.sendToFlow(ctx, e, out)
+		}
+		return true
+	}
+	if skip {
+		return
+	}
+	var item interface{}
+	if e != nil {
+		item = e
+	} else {
+		item = rs[0].Interface()
+	}
+	return o.sendToFlow(ctx, item, out)
+}
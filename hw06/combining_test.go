@@ -0,0 +1,118 @@
+package rxgo_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	res := []int{}
+	rxgo.Merge(rxgo.Just(1, 3, 5), rxgo.Range(10, 12), rxgo.Empty()).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	sort.Ints(res)
+	assert.Equal(t, []int{1, 3, 5, 10, 11}, res, "Merge Test Error!")
+}
+
+func TestMergeWithNever(t *testing.T) {
+	res := []int{}
+	var observer = rxgo.ObserverMonitor{}
+	observer.Next = func(x interface{}) {
+		res = append(res, x.(int))
+		if len(res) == 3 {
+			observer.Unsubscribe()
+		}
+	}
+	observer.Context = func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		observer.CancelObservables = cancel
+		return ctx
+	}
+
+	rxgo.Merge(rxgo.Never(), rxgo.Just(1, 2, 3)).Subscribe(observer)
+	assert.Equal(t, []int{1, 2, 3}, res, "Merge with Never Test Error!")
+}
+
+func TestConcat(t *testing.T) {
+	res := []int{}
+	rxgo.Concat(rxgo.Just(1, 2), rxgo.Empty(), rxgo.Just(3).Map(func(x int) int {
+		return x * 10
+	}), rxgo.Range(4, 6)).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{1, 2, 30, 4, 5}, res, "Concat Test Error!")
+}
+
+func TestZip(t *testing.T) {
+	res := []string{}
+	rxgo.Zip(func(i int, s string) string {
+		return s + string(rune('0'+i))
+	}, rxgo.Range(1, 10), rxgo.Just("a", "b", "c")).Subscribe(func(x string) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []string{"a1", "b2", "c3"}, res, "Zip Test Error!")
+}
+
+func TestZipWithError(t *testing.T) {
+	ee := errors.New("any")
+	res := []interface{}{}
+	rxgo.Zip(func(ctx context.Context, a, b int) int {
+		return a + b
+	}, rxgo.Just(1, ee, 2), rxgo.Just(10, 20)).Subscribe(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			res = append(res, x)
+		},
+		Error: func(e error) {
+			res = append(res, e)
+		},
+	})
+	assert.Equal(t, []interface{}{11, ee, 22}, res, "Zip with error Test Error!")
+}
+
+func TestZipFuncCheck(t *testing.T) {
+	assert.Panics(t, func() {
+		rxgo.Zip(func(a int) int { return a }, rxgo.Just(1), rxgo.Just(2))
+	}, "Zip should check the number of parameters")
+}
+
+func TestCombineLatest(t *testing.T) {
+	cha, chb := make(chan int), make(chan int)
+	res := make(chan int)
+	go func() {
+		rxgo.CombineLatest(func(a, b int) int {
+			return a + b
+		}, rxgo.From(cha), rxgo.From(chb)).Subscribe(func(x int) {
+			res <- x
+		})
+		close(res)
+	}()
+
+	cha <- 1
+	chb <- 10
+	assert.Equal(t, 11, <-res, "CombineLatest Test Error!")
+	cha <- 2
+	assert.Equal(t, 12, <-res, "CombineLatest Test Error!")
+	chb <- 20
+	assert.Equal(t, 22, <-res, "CombineLatest Test Error!")
+	close(cha)
+	chb <- 30
+	assert.Equal(t, 32, <-res, "CombineLatest Test Error!")
+	close(chb)
+	_, ok := <-res
+	assert.False(t, ok, "CombineLatest should complete when all sources complete")
+}
+
+func TestCombineLatestWithEmpty(t *testing.T) {
+	res := []int{}
+	rxgo.CombineLatest(func(a, b int) int {
+		return a + b
+	}, rxgo.Empty(), rxgo.Never()).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{}, res, "CombineLatest with Empty Test Error!")
+}
@@ -0,0 +1,199 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Conditional and boolean operators. Errors are not items to test, they flow at once,
+// so an Observable emitting only errors is empty
+
+var ambSource = rangeSource
+
+// All emits true if `func(x anytype) bool` is true for all the items emitted by an Observable, true for an empty one.
+// It emits false and stops the Observable at once when an item fails the test
+func (parent *Observable) All(f interface{}) (o *Observable) {
+	o = parent.newFilterObservable("All")
+	o.setFilterFunc(f)
+	o.operator = testOperator(false)
+	return o
+}
+
+// Any emits true and stops the Observable at once when `func(x anytype) bool` is true for an item,
+// or emits false when the Observable completes
+func (parent *Observable) Any(f interface{}) (o *Observable) {
+	o = parent.newFilterObservable("Any")
+	o.setFilterFunc(f)
+	o.operator = testOperator(true)
+	return o
+}
+
+// Contains emits true if an Observable emits an item which equals x by reflect.DeepEqual, or false.
+// Errors flow at once
+func (parent *Observable) Contains(x interface{}) (o *Observable) {
+	o = parent.Any(func(item interface{}) bool {
+		return reflect.DeepEqual(item, x)
+	})
+	o.Name = "Contains"
+	// the test function takes interface{}, but errors are not tested
+	o.flip_accept_error = false
+	return o
+}
+
+// testOperator emits `found` at the first item whose test result is found, or !found on completion
+func testOperator(found bool) flowOperator {
+	return flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			for x := range in {
+				if !isError(x) || o.flip_accept_error {
+					b, e, stop := o.testItem(ctx, x)
+					if stop {
+						o.stopFlow(ctx, e, in, out)
+						return
+					}
+					if e == nil && b == found {
+						stopUpstream(ctx, in)
+						o.sendToFlow(ctx, found, out)
+						return
+					}
+					if e == nil {
+						continue
+					}
+					x = e
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+			stopUpstream(ctx, in)
+			o.sendToFlow(ctx, !found, out)
+		},
+	}
+}
+
+// SequenceEqual emits true if an Observable and the other one emit equal items in the same order
+// by reflect.DeepEqual, or false at the first difference. Both are stopped once the result is known
+func (parent *Observable) SequenceEqual(other *Observable) (o *Observable) {
+	o = parent.newFilterObservable("SequenceEqual")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			octx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			oin := other.connectFlow(octx)
+			defer stopUpstream(ctx, in)
+
+			// get the next item of a flow, errors are sent to out
+			next := func(ch chan interface{}) (x interface{}, ok, end bool) {
+				for x = range ch {
+					if !isError(x) {
+						return x, true, false
+					}
+					if o.sendToFlow(ctx, x, out) {
+						return nil, false, true
+					}
+				}
+				return
+			}
+			for {
+				a, oka, end := next(in)
+				if end {
+					return
+				}
+				b, okb, end := next(oin)
+				if end {
+					return
+				}
+				if !oka || !okb || !reflect.DeepEqual(a, b) {
+					o.sendToFlow(ctx, oka == okb && !oka, out)
+					return
+				}
+			}
+		},
+	}
+	return o
+}
+
+// DefaultIfEmpty emits the items emitted by an Observable, or x if it completes without any item
+func (parent *Observable) DefaultIfEmpty(x interface{}) (o *Observable) {
+	o = parent.SwitchIfEmpty(Just(x))
+	o.Name = "DefaultIfEmpty"
+	return o
+}
+
+// SwitchIfEmpty emits the items emitted by an Observable, or the items of the other one if it completes without any item
+func (parent *Observable) SwitchIfEmpty(other *Observable) (o *Observable) {
+	o = parent.newFilterObservable("SwitchIfEmpty")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			empty := true
+			for x := range in {
+				if !isError(x) {
+					empty = false
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+			if empty {
+				o.forwardFlow(ctx, other, out)
+			}
+		},
+	}
+	return o
+}
+
+// Amb emits all the items of the Observable which emits an item or error first, and stops the others
+func Amb(obs ...*Observable) *Observable {
+	o := newGeneratorObservable("Amb")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		var wg sync.WaitGroup
+		var once sync.Once
+		winner := -1
+		// all the contexts exist before any source may win and cancel the others
+		cancels := make([]context.CancelFunc, len(obs))
+		ctxs := make([]context.Context, len(obs))
+		for i := range obs {
+			ctxs[i], cancels[i] = context.WithCancel(ctx)
+		}
+		for i, ob := range obs {
+			cancel := cancels[i]
+			ch := ob.connectFlow(ctxs[i])
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer cancel()
+				x, ok := <-ch
+				if !ok {
+					return
+				}
+				once.Do(func() {
+					winner = i
+					for j, c := range cancels {
+						if j != i {
+							c()
+						}
+					}
+				})
+				if winner != i {
+					for range ch {
+					}
+					return
+				}
+				for ; ok; x, ok = <-ch {
+					if o.sendToFlow(ctx, x, out) {
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	o.operator = ambSource
+	return o
+}
@@ -0,0 +1,118 @@
+package rxgo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	res := collect(rxgo.Just(2, 4, 6).All(func(x int) bool {
+		return x%2 == 0
+	}))
+	assert.Equal(t, []interface{}{true}, res, "All Test Error!")
+
+	res = collect(rxgo.Interval(time.Millisecond).All(func(x int) bool {
+		return x < 3
+	}))
+	assert.Equal(t, []interface{}{false}, res, "All Stop Test Error!")
+
+	res = collect(rxgo.Empty().All(func(x int) bool {
+		return false
+	}))
+	assert.Equal(t, []interface{}{true}, res, "All Empty Test Error!")
+}
+
+func TestAny(t *testing.T) {
+	res := collect(rxgo.Interval(time.Millisecond).Any(func(x int) bool {
+		return x == 3
+	}))
+	assert.Equal(t, []interface{}{true}, res, "Any Test Error!")
+
+	e := errors.New("oops")
+	res = collect(rxgo.Just(1, e, 3).Any(func(x int) bool {
+		return x > 5
+	}))
+	assert.Equal(t, []interface{}{e, false}, res, "Any Error Test Error!")
+}
+
+func TestContains(t *testing.T) {
+	res := collect(rxgo.Just([]int{1}, []int{2, 3}).Contains([]int{2, 3}))
+	assert.Equal(t, []interface{}{true}, res, "Contains Test Error!")
+
+	res = collect(rxgo.Range(0, 5).Contains(9))
+	assert.Equal(t, []interface{}{false}, res, "Contains Missing Test Error!")
+
+	e := errors.New("oops")
+	res = collect(rxgo.Just(1, e, 2).Contains(5))
+	assert.Equal(t, []interface{}{e, false}, res, "Contains should forward errors")
+}
+
+func TestSequenceEqual(t *testing.T) {
+	res := collect(rxgo.Range(1, 4).SequenceEqual(rxgo.Just(1, 2, 3)))
+	assert.Equal(t, []interface{}{true}, res, "SequenceEqual Test Error!")
+
+	res = collect(rxgo.Just(1, 2).SequenceEqual(rxgo.Just(1, 2, 3)))
+	assert.Equal(t, []interface{}{false}, res, "SequenceEqual Length Test Error!")
+
+	res = collect(rxgo.Interval(time.Millisecond).SequenceEqual(rxgo.Just(0, 1, 5)))
+	assert.Equal(t, []interface{}{false}, res, "SequenceEqual Endless Test Error!")
+
+	e := errors.New("oops")
+	res = collect(rxgo.Just(1, e, 2).SequenceEqual(rxgo.Just(1, 2)))
+	assert.Equal(t, []interface{}{e, true}, res, "SequenceEqual Error Test Error!")
+}
+
+func TestDefaultIfEmpty(t *testing.T) {
+	res := collect(rxgo.Empty().DefaultIfEmpty(7))
+	assert.Equal(t, []interface{}{7}, res, "DefaultIfEmpty Test Error!")
+
+	res = collect(rxgo.Just(1, 2).DefaultIfEmpty(7))
+	assert.Equal(t, []interface{}{1, 2}, res, "DefaultIfEmpty NotEmpty Test Error!")
+}
+
+func TestSwitchIfEmpty(t *testing.T) {
+	e := errors.New("oops")
+	res := collect(rxgo.Throw(e).SwitchIfEmpty(rxgo.Just(1, 2)))
+	assert.Equal(t, []interface{}{e, 1, 2}, res, "SwitchIfEmpty Test Error!")
+}
+
+func TestAmb(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	slow := rxgo.Timer(20 * time.Millisecond).SetScheduler(s).Map(func(x int) string {
+		return "slow"
+	})
+	fast := rxgo.Timer(10 * time.Millisecond).SetScheduler(s).Map(func(x int) string {
+		return "fast"
+	})
+	done := make(chan []interface{})
+	go func() {
+		done <- collect(rxgo.Amb(slow, fast))
+	}()
+	s.Advance(10 * time.Millisecond)
+	s.Advance(10 * time.Millisecond)
+	assert.Equal(t, []interface{}{"fast"}, <-done, "Amb Test Error!")
+
+	res := collect(rxgo.Amb(rxgo.Never(), rxgo.Just(1, 2)))
+	assert.Equal(t, []interface{}{1, 2}, res, "Amb Never Test Error!")
+}
+
+func TestAmbManySources(t *testing.T) {
+	for n := 0; n < 20; n++ {
+		obs := []*rxgo.Observable{rxgo.Just(-1)}
+		for i := 0; i < 20; i++ {
+			ob := rxgo.Range(0, 100)
+			for j := 0; j < 10; j++ {
+				ob = ob.Map(func(x int) int {
+					return x
+				})
+			}
+			obs = append(obs, ob)
+		}
+		res := collect(rxgo.Amb(obs...))
+		assert.NotEmpty(t, res, "Amb Test Error!")
+	}
+}
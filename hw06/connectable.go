@@ -0,0 +1,249 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"sync"
+)
+
+// multicast delivers items to all of its subscribers, and replays the latest items to late subscribers
+type multicast struct {
+	mu     sync.Mutex
+	subs   map[*mcSubscriber]bool
+	replay []interface{} // items replayed to late subscribers
+	size   int           // max length of replay, negative for unlimited
+	once   bool          // hand the replay over to the first subscriber, and replay nothing later
+	done   bool
+}
+
+type mcSubscriber struct {
+	ctx context.Context
+	ch  chan interface{}
+}
+
+func newMulticast(size int) *multicast {
+	return &multicast{subs: make(map[*mcSubscriber]bool), size: size}
+}
+
+// subscribe returns the subscriber and items replayed to it. done is true if the multicast has completed
+func (m *multicast) subscribe(ctx context.Context) (s *mcSubscriber, replay []interface{}, done bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	replay = append(replay, m.replay...)
+	if m.once {
+		m.replay, m.size, m.once = nil, 0, false
+	}
+	if m.done {
+		return nil, replay, true
+	}
+	s = &mcSubscriber{ctx, make(chan interface{}, BufferLen)}
+	m.subs[s] = true
+	return s, replay, false
+}
+
+func (m *multicast) unsubscribe(s *mcSubscriber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.subs[s] {
+		delete(m.subs, s)
+		close(s.ch)
+	}
+}
+
+// next sends an item to all subscribers, a slow subscriber blocks the others
+func (m *multicast) next(x interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.done {
+		return
+	}
+	if m.size != 0 {
+		m.replay = append(m.replay, x)
+		if m.size > 0 && len(m.replay) > m.size {
+			m.replay = m.replay[len(m.replay)-m.size:]
+		}
+	}
+	for s := range m.subs {
+		select {
+		case s.ch <- x:
+		case <-s.ctx.Done():
+		}
+	}
+}
+
+// complete all subscribers. if forget is true, the late subscribers get no replayed item
+func (m *multicast) complete(forget bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.done {
+		return
+	}
+	m.done = true
+	if forget {
+		m.replay = nil
+	}
+	for s := range m.subs {
+		delete(m.subs, s)
+		close(s.ch)
+	}
+}
+
+// multicast node implementation of streamOperator. It subscribes the multicast when connected,
+// so that no item is missed if the multicast is fed after Subscribe
+type multicastOperator struct {
+	hub     func() *multicast // get the multicast to subscribe
+	release func()            // called when the subscription ends, may be nil
+}
+
+func (mop multicastOperator) op(ctx context.Context, o *Observable) {
+	out := o.outflow
+	hub := mop.hub()
+	s, replay, done := hub.subscribe(ctx)
+
+	go func() {
+		defer o.closeFlow(out)
+		if mop.release != nil {
+			defer mop.release()
+		}
+		for _, x := range replay {
+			if o.sendToFlow(ctx, x, out) {
+				break
+			}
+		}
+		if done {
+			return
+		}
+		defer hub.unsubscribe(s)
+		for {
+			select {
+			case x, ok := <-s.ch:
+				if !ok || o.sendToFlow(ctx, x, out) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// A ConnectableObservable resembles an ordinary Observable, except that it does not begin emitting items
+// when it is subscribed to, but only when its Connect method is called. All of its subscribers
+// share one execution of the source Observable
+type ConnectableObservable struct {
+	*Observable
+	source  *Observable
+	replay  int // the number of items replayed to late subscribers
+	mu      sync.Mutex
+	hub     *multicast
+	running bool
+	cancel  context.CancelFunc
+	refs    int
+}
+
+// Publish converts an ordinary Observable into a ConnectableObservable
+func (parent *Observable) Publish() *ConnectableObservable {
+	return parent.newConnectable("Publish", 0)
+}
+
+// Replay converts an ordinary Observable into a ConnectableObservable, which replays the latest n items
+// to the subscribers subscribing late. All items are replayed if n is negative
+func (parent *Observable) Replay(n int) *ConnectableObservable {
+	return parent.newConnectable("Replay", n)
+}
+
+// Share returns an Observable which shares one execution of the source among all of its subscribers.
+// It is the same as Publish().RefCount()
+func (parent *Observable) Share() *Observable {
+	o := parent.Publish().RefCount()
+	o.Name = "Share"
+	return o
+}
+
+func (parent *Observable) newConnectable(name string, replay int) *ConnectableObservable {
+	co := &ConnectableObservable{source: parent, replay: replay, hub: newMulticast(replay)}
+	co.Observable = newGeneratorObservable(name)
+	co.operator = multicastOperator{co.getHub, nil}
+	return co
+}
+
+func (co *ConnectableObservable) getHub() *multicast {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	return co.hub
+}
+
+// Connect makes the source begin emitting items to the subscribers, and returns a function to disconnect it.
+// Connect again after the source completed will start a new execution of the source
+func (co *ConnectableObservable) Connect() (disconnect context.CancelFunc) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	return co.connectLocked()
+}
+
+func (co *ConnectableObservable) connectLocked() context.CancelFunc {
+	if co.running {
+		return co.cancel
+	}
+	if co.hub.done {
+		co.hub = newMulticast(co.replay)
+	}
+	hub := co.hub
+	ctx, cancel := context.WithCancel(context.Background())
+	co.running, co.cancel = true, cancel
+
+	ch := co.source.connectFlow(ctx)
+	go func() {
+		for x := range ch {
+			hub.next(x)
+		}
+		hub.complete(false)
+		cancel()
+
+		co.mu.Lock()
+		if co.hub == hub {
+			co.running = false
+		}
+		co.mu.Unlock()
+	}()
+	return cancel
+}
+
+// RefCount makes a ConnectableObservable behave like an ordinary Observable. It connects when the first
+// subscriber subscribes, and disconnects when all the subscribers have unsubscribed
+func (co *ConnectableObservable) RefCount() *Observable {
+	o := newGeneratorObservable("RefCount")
+	o.operator = refCountOperator{co}
+	return o
+}
+
+// refCount node implementation of streamOperator
+type refCountOperator struct {
+	co *ConnectableObservable
+}
+
+func (rop refCountOperator) op(ctx context.Context, o *Observable) {
+	co := rop.co
+	co.mu.Lock()
+	if !co.running && co.hub.done {
+		co.hub = newMulticast(co.replay)
+	}
+	hub := co.hub
+	co.refs++
+	co.mu.Unlock()
+
+	// subscribe before connecting, so that no item is missed
+	release := func() {
+		co.mu.Lock()
+		defer co.mu.Unlock()
+		co.refs--
+		if co.refs == 0 && co.running && co.hub == hub {
+			co.cancel()
+		}
+	}
+	multicastOperator{func() *multicast { return hub }, release}.op(ctx, o)
+	co.Connect()
+}
@@ -0,0 +1,162 @@
+package rxgo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// a source counting how many times it is executed
+func countedSource(count *int32, items ...int) *rxgo.Observable {
+	return rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		atomic.AddInt32(count, 1)
+		for _, x := range items {
+			if send(x) {
+				return
+			}
+		}
+	})
+}
+
+// subscribe o in a goroutine, and wait until it is connected
+func subscribeAsync(o *rxgo.Observable, res *[]int, wg *sync.WaitGroup) {
+	connected := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		o.Subscribe(rxgo.ObserverMonitor{
+			Next: func(x interface{}) {
+				*res = append(*res, x.(int))
+			},
+			AfterConnected: func() {
+				close(connected)
+			},
+		})
+	}()
+	<-connected
+}
+
+func TestPublish(t *testing.T) {
+	var count int32
+	co := countedSource(&count, 1, 2, 3).Map(func(x int) int {
+		return x * 10
+	}).Publish()
+
+	var wg sync.WaitGroup
+	res1, res2 := []int{}, []int{}
+	subscribeAsync(co.Observable, &res1, &wg)
+	subscribeAsync(co.Observable, &res2, &wg)
+	co.Connect()
+	wg.Wait()
+
+	assert.Equal(t, int32(1), count, "Publish should execute the source once")
+	assert.Equal(t, []int{10, 20, 30}, res1, "Publish Test Error!")
+	assert.Equal(t, []int{10, 20, 30}, res2, "Publish Test Error!")
+
+	// subscribers after completion get nothing, until connected again
+	res3 := []int{}
+	co.Subscribe(func(x int) {
+		res3 = append(res3, x)
+	})
+	assert.Equal(t, []int{}, res3, "Publish Test Error!")
+}
+
+func TestPublishDisconnect(t *testing.T) {
+	ch := make(chan int)
+	co := rxgo.From(ch).Publish()
+
+	var wg sync.WaitGroup
+	res := []int{}
+	subscribeAsync(co.Observable, &res, &wg)
+	disconnect := co.Connect()
+	ch <- 1
+	ch <- 2
+	ch <- 3 // the source has emitted 2 after 3 is received
+	disconnect()
+	wg.Wait()
+	assert.False(t, len(res) > 3, "Disconnect Test Error!")
+	assert.Equal(t, []int{1, 2}, res[:2], "Disconnect Test Error!")
+}
+
+func TestReplay(t *testing.T) {
+	var count int32
+	co := countedSource(&count, 1, 2, 3, 4).Replay(2)
+
+	var wg sync.WaitGroup
+	res1 := []int{}
+	subscribeAsync(co.Observable, &res1, &wg)
+	co.Connect()
+	wg.Wait()
+
+	res2 := []int{}
+	co.Subscribe(func(x int) {
+		res2 = append(res2, x)
+	})
+	assert.Equal(t, int32(1), count, "Replay should execute the source once")
+	assert.Equal(t, []int{1, 2, 3, 4}, res1, "Replay Test Error!")
+	assert.Equal(t, []int{3, 4}, res2, "Replay Test Error!")
+}
+
+func TestShare(t *testing.T) {
+	var count int32
+	ch := make(chan int)
+	canceled := make(chan struct{})
+	shared := rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		atomic.AddInt32(&count, 1)
+		for {
+			select {
+			case x := <-ch:
+				send(x)
+			case <-ctx.Done():
+				close(canceled)
+				return
+			}
+		}
+	}).Share()
+
+	subscriber := func(res chan int, wg *sync.WaitGroup) rxgo.ObserverMonitor {
+		connected := make(chan struct{})
+		var ob = rxgo.ObserverMonitor{}
+		ob.Next = func(x interface{}) {
+			res <- x.(int)
+		}
+		ob.Context = func() context.Context {
+			ctx, cancel := context.WithCancel(context.Background())
+			ob.CancelObservables = cancel
+			return ctx
+		}
+		ob.AfterConnected = func() {
+			close(connected)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shared.Subscribe(ob)
+		}()
+		<-connected
+		return ob
+	}
+
+	var wg1, wg2 sync.WaitGroup
+	res1, res2 := make(chan int, 10), make(chan int, 10)
+	ob1 := subscriber(res1, &wg1)
+	ch <- 1
+	assert.Equal(t, 1, <-res1, "Share Test Error!")
+	ob2 := subscriber(res2, &wg2)
+	ch <- 2
+	assert.Equal(t, []int{2, 2}, []int{<-res1, <-res2}, "Share Test Error!")
+
+	ob1.Unsubscribe()
+	wg1.Wait()
+	ch <- 3
+	assert.Equal(t, 3, <-res2, "Share should keep the source running while it has subscribers")
+
+	ob2.Unsubscribe()
+	wg2.Wait()
+	<-canceled
+	assert.Equal(t, int32(1), count, "Share should execute the source once")
+}
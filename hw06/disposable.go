@@ -0,0 +1,90 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Disposable is a subscription running in background
+type Disposable interface {
+	Dispose()              // unsubscribe, all goroutines of the Observables will exit
+	Done() <-chan struct{} // closed when the subscription ends
+	Err() error            // nil if the Observable completed, or the error of the context that stopped it
+}
+
+// SubscribeAsync is Subscribe without blocking. The observer is the same as Subscribe, and it is called in another goroutine.
+// It returns when the Observables are connected, so that no item of a hot Observable is missed after it returns
+func (o *Observable) SubscribeAsync(ob interface{}) Disposable {
+	observer, ok := ob.(Observer)
+	if !ok {
+		// observe function `func(x anytype)`
+		fv, ft := reflect.ValueOf(ob), reflect.TypeOf(ob)
+		if fv.Kind() != reflect.Func || ft.NumIn() != 1 || ft.NumOut() != 0 {
+			panic(ErrFuncOnNext)
+		}
+		observer = ObserverMonitor{Next: func(x interface{}) {
+			fv.Call([]reflect.Value{reflect.ValueOf(x)})
+		}}
+	}
+
+	ctx := context.Background()
+	oc, ctxok := observer.(ObserverWithContext)
+	if ctxok {
+		ctx = oc.GetObserverContext()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	d := &disposable{cancel: cancel, done: make(chan struct{})}
+	connected := make(chan struct{})
+
+	go func() {
+		defer close(d.done)
+		o.Subscribe(ObserverMonitor{
+			Next:      observer.OnNext,
+			Error:     observer.OnError,
+			Completed: observer.OnCompleted,
+			Context: func() context.Context {
+				return ctx
+			},
+			AfterConnected: func() {
+				close(connected)
+				if ctxok {
+					oc.OnConnected()
+				}
+			},
+			CancelObservables: cancel,
+		})
+		d.mu.Lock()
+		d.err = ctx.Err()
+		d.mu.Unlock()
+		cancel()
+	}()
+
+	<-connected
+	return d
+}
+
+type disposable struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+func (d *disposable) Dispose() {
+	d.cancel()
+}
+
+func (d *disposable) Done() <-chan struct{} {
+	return d.done
+}
+
+func (d *disposable) Err() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.err
+}
@@ -0,0 +1,70 @@
+package rxgo_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeAsync(t *testing.T) {
+	before := runtime.NumGoroutine()
+	res := []int{}
+	d := rxgo.Range(0, 5).Map(func(x int) int {
+		return x * 2
+	}).SubscribeAsync(func(x int) {
+		res = append(res, x)
+	})
+	<-d.Done()
+	assert.NoError(t, d.Err(), "SubscribeAsync Test Error!")
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, res, "SubscribeAsync Test Error!")
+	checkGoroutines(t, before)
+}
+
+func TestDispose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	res := make(chan int, 100)
+	endless := rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		for i := 0; !send(i); i++ {
+		}
+	})
+	busy := endless.Map(func(x int) int {
+		return x + 1
+	}).Filter(func(x int) bool {
+		return x%2 == 0
+	}).Debounce(time.Hour)
+	d := rxgo.Merge(busy, rxgo.Interval(time.Millisecond)).SubscribeAsync(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			select {
+			case res <- x.(int):
+			default:
+			}
+		},
+	})
+	<-res
+	d.Dispose()
+	<-d.Done()
+	assert.Equal(t, context.Canceled, d.Err(), "Dispose Test Error!")
+	checkGoroutines(t, before)
+}
+
+func TestSubscribeAsyncHot(t *testing.T) {
+	s := rxgo.NewPublishSubject()
+	res := make(chan int, 10)
+	d := s.SubscribeAsync(func(x int) {
+		res <- x
+	})
+	// connected when SubscribeAsync returns
+	s.OnNext(1)
+	assert.Equal(t, 1, <-res, "SubscribeAsync Test Error!")
+	s.OnCompleted()
+	<-d.Done()
+	assert.NoError(t, d.Err(), "SubscribeAsync Test Error!")
+
+	assert.Panics(t, func() {
+		rxgo.Just(1).SubscribeAsync(1)
+	}, "SubscribeAsync should check the observer")
+}
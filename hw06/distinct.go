@@ -0,0 +1,179 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"reflect"
+)
+
+// the key of Distinct can not be compared, the item flows as FlowableError.Elements
+var ErrKeyNotComparable = errors.New("Key is not comparable!")
+
+// SetKeyMemory limits the number of keys remembered by Distinct and DistinctBy for long-running flows.
+// The least recently seen key is forgotten first, so an item may be emitted again after its key is forgotten.
+// It is 0 by default, which means unlimited
+func (o *Observable) SetKeyMemory(size uint) *Observable {
+	o.key_memory = size
+	return o
+}
+
+// isMapKey returns true if the key can be used as a map key without panic.
+// The dynamic value is checked, since a struct or an array of interfaces may hold a slice
+func isMapKey(key interface{}) bool {
+	return key == nil || reflect.ValueOf(key).Comparable()
+}
+
+// keySet is a set of keys, which forgets the least recently seen key when it is full
+type keySet struct {
+	size  uint // 0 for unlimited
+	keys  map[interface{}]*list.Element
+	order *list.List // recently seen keys in front
+}
+
+func newKeySet(size uint) *keySet {
+	return &keySet{size: size, keys: make(map[interface{}]*list.Element), order: list.New()}
+}
+
+// see a key, it returns true if the key is seen before
+func (s *keySet) see(key interface{}) (seen bool) {
+	if e, ok := s.keys[key]; ok {
+		s.order.MoveToFront(e)
+		return true
+	}
+	s.keys[key] = s.order.PushFront(key)
+	if s.size > 0 && uint(s.order.Len()) > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.keys, oldest.Value)
+	}
+	return false
+}
+
+// Distinct suppress duplicate items emitted by an Observable.
+// An item which can not be a map key flows as FlowableError with ErrKeyNotComparable, DistinctBy may help
+func (parent *Observable) Distinct() (o *Observable) {
+	return parent.newDistinctObservable("Distinct")
+}
+
+// DistinctBy suppress items emitted by an Observable, whose keys returned by `func(x anytype) anytype` are seen before.
+// An item whose key can not be a map key flows as FlowableError with ErrKeyNotComparable
+func (parent *Observable) DistinctBy(keyFunc interface{}) (o *Observable) {
+	fv := reflect.ValueOf(keyFunc)
+	inType := []reflect.Type{typeAny}
+	outType := []reflect.Type{typeAny}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b {
+		panic(ErrFuncFlip)
+	}
+
+	o = parent.newDistinctObservable("DistinctBy")
+	o.flip_accept_error = checkFuncAcceptError(fv)
+	o.flip_sup_ctx = ctx_sup
+	o.flip = fv.Interface()
+	return
+}
+
+// distinct items by keys returned from the user function, or by the items themselves if there is no function
+func (parent *Observable) newDistinctObservable(name string) (o *Observable) {
+	o = parent.newFilterObservable(name)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			seen := newKeySet(o.key_memory)
+			for x := range in {
+				if !isError(x) || o.flip_accept_error {
+					key := x
+					if o.flip != nil {
+						rs, skip, stop, e := o.callFlip(ctx, reflect.ValueOf(x))
+						if stop {
+							o.stopFlow(ctx, e, in, out)
+							return
+						}
+						if skip {
+							continue
+						}
+						if e != nil {
+							if o.sendToFlow(ctx, e, out) {
+								return
+							}
+							continue
+						}
+						key = rs[0].Interface()
+					}
+					if !isMapKey(key) {
+						if o.sendToFlow(ctx, FlowableError{Err: ErrKeyNotComparable, Elements: x}, out) {
+							return
+						}
+						continue
+					}
+					if seen.see(key) {
+						continue
+					}
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+		},
+	}
+	return
+}
+
+// DistinctUntilChanged suppress an item emitted by an Observable if it equals the previous one, by reflect.DeepEqual
+func (parent *Observable) DistinctUntilChanged() (o *Observable) {
+	o = parent.DistinctUntilChangedFunc(reflect.DeepEqual)
+	o.Name = "DistinctUntilChanged"
+	return
+}
+
+// DistinctUntilChangedFunc suppress an item emitted by an Observable if it equals the previous one,
+// by `func(previous, current anytype) bool`. Errors flow at once, they are not compared
+func (parent *Observable) DistinctUntilChangedFunc(equal interface{}) (o *Observable) {
+	fv := reflect.ValueOf(equal)
+	inType := []reflect.Type{typeAny, typeAny}
+	outType := []reflect.Type{typeBool}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b {
+		panic(ErrFuncFlip)
+	}
+
+	o = parent.newFilterObservable("DistinctUntilChangedFunc")
+	o.flip_sup_ctx = ctx_sup
+	o.flip = fv.Interface()
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			var prev interface{}
+			has := false
+			for x := range in {
+				if !isError(x) {
+					if has {
+						rs, skip, stop, e := o.callFlip(ctx, reflect.ValueOf(prev), reflect.ValueOf(x))
+						if stop {
+							o.stopFlow(ctx, e, in, out)
+							return
+						}
+						if skip {
+							continue
+						}
+						if e != nil {
+							x = e
+						} else if rs[0].Bool() {
+							continue
+						}
+					}
+					if !isError(x) {
+						prev, has = x, true
+					}
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+		},
+	}
+	return
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"time"
+)
+
+// Error-handling operators regard the first error emitted by the source Observable as its termination,
+// as ReactiveX does. The source is unsubscribed at the error, and then retried or replaced.
+
+var retrySource = rangeSource
+var catchSource = rangeSource
+
+// Retry resubscribes the source Observable if it emits an error, at most n times,
+// or endless when n is negative. The last error is emitted if all retries fail
+func (parent *Observable) Retry(n int) *Observable {
+	o := parent.RetryWhen(func(err error, count int) *Observable {
+		if n >= 0 && count > n {
+			return nil
+		}
+		return Just(count)
+	})
+	o.Name = "Retry"
+	return o
+}
+
+// RetryWithBackoff resubscribes the source Observable if it emits an error, at most n times.
+// It waits before each retry, the delay starts from initial and doubles every time, but never exceeds max.
+// Delays run on the scheduler of the source Observable, or the one set to the returned Observable
+func (parent *Observable) RetryWithBackoff(n int, initial, max time.Duration) *Observable {
+	var o *Observable
+	o = parent.RetryWhen(func(err error, count int) *Observable {
+		if n >= 0 && count > n {
+			return nil
+		}
+		delay := initial
+		for i := 1; i < count && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		s := o.scheduler
+		if s == nil {
+			s = parent.getScheduler()
+		}
+		return Timer(delay).SetScheduler(s)
+	})
+	o.Name = "RetryWithBackoff"
+	return o
+}
+
+// RetryWhen resubscribes the source Observable when it emits an error.
+// At each error, the notifier is called with the error and the number of retries including this one,
+// and the source is resubscribed once the returned Observable emits an item.
+// The error is emitted and the flow stops if the notifier returns nil or an Observable which completes without any item,
+// and the error of the returned Observable is emitted instead if it emits an error first
+func (parent *Observable) RetryWhen(notifier func(err error, count int) *Observable) *Observable {
+	o := newGeneratorObservable("RetryWhen")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		for count := 1; ; count++ {
+			err, end := o.forwardUntilError(ctx, parent, out)
+			if err == nil || end {
+				return
+			}
+			signal := notifier(err, count)
+			if signal == nil {
+				o.sendToFlow(ctx, err, out)
+				return
+			}
+			item, ok := firstItem(ctx, signal)
+			if ctx.Err() != nil {
+				return
+			}
+			if !ok {
+				o.sendToFlow(ctx, err, out)
+				return
+			}
+			if e, ok := item.(error); ok {
+				o.sendToFlow(ctx, e, out)
+				return
+			}
+		}
+	}
+	o.operator = retrySource
+	return o
+}
+
+// Catch replaces the source Observable with the one returned by f when the source emits an error
+func (parent *Observable) Catch(f func(err error) *Observable) *Observable {
+	o := newGeneratorObservable("Catch")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		err, end := o.forwardUntilError(ctx, parent, out)
+		if err == nil || end {
+			return
+		}
+		if next := f(err); next != nil {
+			o.forwardFlow(ctx, next, out)
+		}
+	}
+	o.operator = catchSource
+	return o
+}
+
+// OnErrorResumeNext replaces the source Observable with next when the source emits an error
+func (parent *Observable) OnErrorResumeNext(next *Observable) *Observable {
+	o := parent.Catch(func(err error) *Observable {
+		return next
+	})
+	o.Name = "OnErrorResumeNext"
+	return o
+}
+
+// OnErrorReturn emits the item returned by f instead of the error emitted by the source Observable, and then completes
+func (parent *Observable) OnErrorReturn(f func(err error) interface{}) *Observable {
+	o := parent.Catch(func(err error) *Observable {
+		return Just(f(err))
+	})
+	o.Name = "OnErrorReturn"
+	return o
+}
+
+// subscribe the Observable and send its items to out until it emits an error,
+// end is true if the flow is stopped or unsubscribed
+func (o *Observable) forwardUntilError(ctx context.Context, ob *Observable, out chan interface{}) (err error, end bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for item := range ob.connectFlow(ctx) {
+		if e, ok := item.(error); ok {
+			return e, false
+		}
+		if end = o.sendToFlow(ctx, item, out); end {
+			return
+		}
+	}
+	return nil, ctx.Err() != nil
+}
@@ -0,0 +1,131 @@
+package rxgo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFlaky = errors.New("flaky")
+
+// a source emitting 1, 2 and failing in the first n subscriptions
+func flakySource(n int, subscribed func()) *rxgo.Observable {
+	count := 0
+	return rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		count++
+		if subscribed != nil {
+			subscribed()
+		}
+		if send(1) || send(2) {
+			return
+		}
+		if count <= n {
+			send(errFlaky)
+			send(9) // never received, the source is unsubscribed at the error
+			return
+		}
+		send(3)
+	})
+}
+
+func subscribeWithError(o *rxgo.Observable) (res []int, errs []error) {
+	res = []int{}
+	o.Subscribe(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			res = append(res, x.(int))
+		},
+		Error: func(e error) {
+			errs = append(errs, e)
+		},
+	})
+	return
+}
+
+func TestRetry(t *testing.T) {
+	res, errs := subscribeWithError(flakySource(2, nil).Retry(2))
+	assert.Equal(t, []int{1, 2, 1, 2, 1, 2, 3}, res, "Retry Test Error!")
+	assert.Empty(t, errs, "Retry Test Error!")
+
+	res, errs = subscribeWithError(flakySource(3, nil).Retry(2))
+	assert.Equal(t, []int{1, 2, 1, 2, 1, 2}, res, "Retry Test Error!")
+	assert.Equal(t, []error{errFlaky}, errs, "Retry should emit the last error")
+
+	res, _ = subscribeWithError(flakySource(5, nil).Retry(-1))
+	assert.Equal(t, 13, len(res), "Retry Test Error!")
+}
+
+func TestRetryWhen(t *testing.T) {
+	counts := []int{}
+	res, errs := subscribeWithError(flakySource(5, nil).RetryWhen(func(err error, count int) *rxgo.Observable {
+		counts = append(counts, count)
+		if count > 1 {
+			return rxgo.Empty()
+		}
+		return rxgo.Just(true)
+	}))
+	assert.Equal(t, []int{1, 2}, counts, "RetryWhen Test Error!")
+	assert.Equal(t, []int{1, 2, 1, 2}, res, "RetryWhen Test Error!")
+	assert.Equal(t, []error{errFlaky}, errs, "RetryWhen Test Error!")
+
+	errStop := errors.New("stop")
+	_, errs = subscribeWithError(flakySource(5, nil).RetryWhen(func(err error, count int) *rxgo.Observable {
+		return rxgo.Throw(errStop)
+	}))
+	assert.Equal(t, []error{errStop}, errs, "RetryWhen should emit the error of the notifier")
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	start := s.Now()
+	at := []time.Duration{}
+	done := make(chan struct{})
+	var errs []error
+	go func() {
+		_, errs = subscribeWithError(flakySource(5, func() {
+			at = append(at, s.Now().Sub(start))
+		}).SetScheduler(s).RetryWithBackoff(3, 10*time.Millisecond, 25*time.Millisecond))
+		close(done)
+	}()
+
+	for i := 0; i < 20; i++ {
+		s.Advance(5 * time.Millisecond)
+	}
+	<-done
+	ms := time.Millisecond
+	assert.Equal(t, []time.Duration{0, 10 * ms, 30 * ms, 55 * ms}, at, "RetryWithBackoff Test Error!")
+	assert.Equal(t, []error{errFlaky}, errs, "RetryWithBackoff Test Error!")
+}
+
+func TestCatch(t *testing.T) {
+	var caught error
+	res, errs := subscribeWithError(flakySource(1, nil).Catch(func(err error) *rxgo.Observable {
+		caught = err
+		return rxgo.Just(7, 8)
+	}))
+	assert.Equal(t, errFlaky, caught, "Catch Test Error!")
+	assert.Equal(t, []int{1, 2, 7, 8}, res, "Catch Test Error!")
+	assert.Empty(t, errs, "Catch Test Error!")
+
+	res, _ = subscribeWithError(rxgo.Just(1, 2).Catch(func(err error) *rxgo.Observable {
+		return rxgo.Just(7)
+	}))
+	assert.Equal(t, []int{1, 2}, res, "Catch should not change a flow without error")
+}
+
+func TestOnErrorResumeNext(t *testing.T) {
+	res, errs := subscribeWithError(flakySource(1, nil).OnErrorResumeNext(rxgo.Range(5, 7)))
+	assert.Equal(t, []int{1, 2, 5, 6}, res, "OnErrorResumeNext Test Error!")
+	assert.Empty(t, errs, "OnErrorResumeNext Test Error!")
+}
+
+func TestOnErrorReturn(t *testing.T) {
+	res, errs := subscribeWithError(flakySource(1, nil).OnErrorReturn(func(err error) interface{} {
+		return -1
+	}))
+	assert.Equal(t, []int{1, 2, -1}, res, "OnErrorReturn Test Error!")
+	assert.Empty(t, errs, "OnErrorReturn Test Error!")
+}
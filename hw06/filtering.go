@@ -4,18 +4,16 @@ import (
     "context"
     "errors"
 	"reflect"
-	"sync"
 	"time"
-	"fmt"
 )
 
+// Deprecated: First and Last emit ErrNoItem for an empty flow now
 var ErrInput = errors.New("Input Error!");
 
-var ErrIndex = errors.New("The index is illegal!")
+// First or Last emits it if the Observable completes without any item
+var ErrNoItem = errors.New("No item!")
 
-type filteringOperator struct {
-	opFunc func(ctx context.Context, o *Observable, item reflect.Value, out chan interface{}) (end bool)
-}
+var ErrIndex = errors.New("The index is illegal!")
 
 // initialize a new FilterObservable
 func (parent *Observable) newFilterObservable(name string) (o *Observable) {
@@ -25,335 +23,519 @@ func (parent *Observable) newFilterObservable(name string) (o *Observable) {
     o.pred = parent
     parent.next = o
     o.buf_len = BufferLen
-    o.only_first = false
-    o.only_last = false
-    o.debounce_timespan = 0
-    o.only_distinct = false
-    o.skip = 0
-    o.take = 0
     return
 }
 
-// Debounce only emit an item from an Observable if a particular timespan has passed without it emitting another item
-func (parent *Observable) Debounce(t time.Duration) (o *Observable) {
+// Debounce only emit an item from an Observable if a particular timespan has passed without it emitting another item.
+// The pending item is emitted at once when the Observable completes
+func (parent *Observable) Debounce(timespan time.Duration) (o *Observable) {
 	o = parent.newFilterObservable("Debounce")
-	o.debounce_timespan = t
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
-			}
-			return
-		},
-	}
-	return o
-}
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			clk := o.getScheduler()
+			var timer SchedulerTimer
+			var fire <-chan time.Time
+			var latest interface{}
+			defer func() {
+				if timer != nil {
+					timer.Stop()
+				}
+			}()
 
-// Distinct suppress duplicate items emitted by an Observable
-func (parent *Observable) Distinct() (o *Observable) {
-	o = parent.newFilterObservable("Distinct")
-	o.only_distinct = true
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+			for {
+				select {
+				case x, ok := <-in:
+					if !ok {
+						if fire != nil {
+							o.sendToFlow(ctx, latest, out)
+						}
+						return
+					}
+					if e, ok := x.(error); ok {
+						if o.sendToFlow(ctx, e, out) {
+							return
+						}
+						continue
+					}
+					// restart the quiet period
+					if timer != nil {
+						timer.Stop()
+					}
+					latest = x
+					timer = clk.NewTimer(timespan)
+					fire = timer.C()
+				case <-fire:
+					fire = nil
+					if o.sendToFlow(ctx, latest, out) {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
 			}
-			return
 		},
 	}
 	return o
 }
 
-// ElementAt emit only item n emitted by an Observable
+// ElementAt emit only item n emitted by an Observable, counting from 1, and then stop the Observable.
+// ErrIndex is emitted if n is not positive or the Observable completes before item n. Errors are not counted
 func (parent *Observable) ElementAt(id int) (o *Observable) {
 	o = parent.newFilterObservable("ElementAt.n")
-	o.element_at = id
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			for n := 0; id > 0; {
+				x, ok := <-in
+				if !ok {
+					break
+				}
+				if isError(x) {
+					if o.sendToFlow(ctx, x, out) {
+						return
+					}
+					continue
+				}
+				if n++; n == id {
+					stopUpstream(ctx, in)
+					o.sendToFlow(ctx, x, out)
+					return
+				}
 			}
-			return
+			stopUpstream(ctx, in)
+			o.sendToFlow(ctx, ErrIndex, out)
 		},
 	}
 	return
 }
 
-// First emit only the first item (or the first item that meets some condition) emitted by an Observable
+// First emit only the first item emitted by an Observable, and then stop the Observable.
+// Errors before it flow at once. ErrNoItem is emitted if the Observable completes without any item
 func (parent *Observable) First() (o *Observable) {
 	o = parent.newFilterObservable("First")
-	o.only_first = true
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			for x := range in {
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+				if !isError(x) {
+					stopUpstream(ctx, in)
+					return
+				}
 			}
-			return
+			o.sendToFlow(ctx, ErrNoItem, out)
 		},
 	}
 	return o
 }
 
-// Last emit only the last item (or the last item that meets some condition) emitted by an Observable
+// Last emit only the last item emitted by an Observable when it completes.
+// Errors flow at once. ErrNoItem is emitted if the Observable completes without any item
 func (parent *Observable) Last() (o *Observable) {
 	o = parent.newFilterObservable("Last")
-	o.only_last  = true
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			var last interface{} = ErrNoItem
+			for x := range in {
+				if !isError(x) {
+					last = x
+					continue
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
 			}
-			return
+			o.sendToFlow(ctx, last, out)
 		},
 	}
 	return o
 }
 
 // Sample emit the most recent item emitted by an Observable within periodic time intervals.
+// Nothing is emitted in a interval if the Observable emits no item in it
 func (parent *Observable) Sample(timespan time.Duration) (o *Observable) {
 	o = parent.newFilterObservable("Sample")
-	o.sample_interval = timespan
-    o.element_at = 0
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			ticker := o.getScheduler().NewTicker(timespan)
+			defer ticker.Stop()
+			var latest interface{}
+			pending := false
+
+			for {
+				select {
+				case x, ok := <-in:
+					if !ok {
+						return
+					}
+					if e, ok := x.(error); ok {
+						if o.sendToFlow(ctx, e, out) {
+							return
+						}
+						continue
+					}
+					latest, pending = x, true
+				case <-ticker.C():
+					if !pending {
+						continue
+					}
+					pending = false
+					if o.sendToFlow(ctx, latest, out) {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
 			}
-			return
 		},
 	}
 	return o
 }
 
-// Skip suppress the first n items emitted by an Observable
+// ThrottleLast is an alias of Sample
+func (parent *Observable) ThrottleLast(timespan time.Duration) (o *Observable) {
+	o = parent.Sample(timespan)
+	o.Name = "ThrottleLast"
+	return o
+}
+
+// Skip suppress the first n items emitted by an Observable. Errors are not counted
 func (parent *Observable) Skip(num int) (o *Observable) {
 	o = parent.newFilterObservable("Skip.n")
-	o.skip = num
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			n := 0
+			for x := range in {
+				if !isError(x) && n < num {
+					n++
+					continue
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
 			}
-			return
 		},
 	}
 	return o
 }
 
-// SkipLast suppress the last n items emitted by an Observable
+// SkipLast suppress the last n items emitted by an Observable. An item is emitted when n items have arrived after it.
+// Errors are not counted, they flow at once
 func (parent *Observable) SkipLast(num int) (o *Observable) {
 	o = parent.newFilterObservable("SkipLast.n")
-	o.skip = -num
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			var buf []interface{}
+			for x := range in {
+				if !isError(x) {
+					buf = append(buf, x)
+					if len(buf) <= num {
+						continue
+					}
+					x, buf = buf[0], buf[1:]
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
 			}
-			return
 		},
 	}
 	return o
 }
 
-// Take emit only the first n items emitted by an Observable
+// Take emit only the first n items emitted by an Observable, and then stop the Observable.
+// Errors are not counted
 func (parent *Observable) Take(num int) (o *Observable) {
 	o = parent.newFilterObservable("Take")
-	o.take = num
-    o.is_taking = true
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			for n := 0; n < num; {
+				x, ok := <-in
+				if !ok {
+					return
+				}
+				if !isError(x) {
+					n++
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
 			}
-			return
+			stopUpstream(ctx, in)
 		},
 	}
 	return o
 }
 
-// TakeLast emit only the last n items emitted by an Observable
+// TakeLast emit only the last n items emitted by an Observable when it completes.
+// Errors are not counted, they flow at once
 func (parent *Observable) TakeLast(num int) (o *Observable) {
 	o = parent.newFilterObservable("TakeLast")
-	o.take = -num
-    o.is_taking = true
-    o.operator = filteringOperator {
-		opFunc: func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
-			var params = []reflect.Value{x}
-			x = params[0]
-			if !end {
-				end = o.sendToFlow(ctx, x.Interface(), out)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			var buf []interface{}
+			for x := range in {
+				if isError(x) {
+					if o.sendToFlow(ctx, x, out) {
+						return
+					}
+					continue
+				}
+				if buf = append(buf, x); len(buf) > num {
+					buf = buf[1:]
+				}
+			}
+			for _, x := range buf {
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
 			}
-			return
 		},
 	}
 	return o
 }
 
-func (tsop filteringOperator) op(ctx context.Context, o *Observable) {
-	in := o.pred.outflow
-    out := o.outflow
-    interval := o.debounce_timespan
-    var wg sync.WaitGroup
-    var out_buf []interface{}
-	go func() {
-		is_appear := make(map[interface{}]bool)
-		end := false
-		start := time.Now()
-		sample_start := time.Now()
-		for x := range in {
-			rt := time.Since(start)
-			st := time.Since(sample_start)
-			start = time.Now()
-			if end {
-				continue
-			}
-			if o.sample_interval > 0 && st < o.sample_interval {
-				continue
-			}
-			if interval > time.Duration(0) && rt < interval {
-				continue
-			}
-			xv := reflect.ValueOf(x)
-			if e, ok := x.(error); ok && !o.flip_accept_error {
-				o.sendToFlow(ctx, e, out)
-				continue
-			}
-			o.mu.Lock()
-			out_buf = append(out_buf, x)
-			o.mu.Unlock()
-			if o.element_at > 0 {
-				continue
-			}
-			if o.take != 0 || o.skip != 0 {
-				continue
-			}
-			if o.only_last {
-				continue
-			}
-			if o.only_distinct && is_appear[xv.Interface()] {
-				continue
-			}
-			o.mu.Lock()
-			is_appear[xv.Interface()] = true
-			o.mu.Unlock()
-			if interval > time.Duration(0) {
-				if len(out_buf) > 2 {
-					xv = reflect.ValueOf(out_buf[len(out_buf) - 2])
+// TakeWhile emit items emitted by an Observable while `func(x anytype) bool` is true for them,
+// and then stop the Observable. Errors flow at once unless the function accepts errors
+func (parent *Observable) TakeWhile(f interface{}) (o *Observable) {
+	o = parent.newFilterObservable("TakeWhile")
+	o.setFilterFunc(f)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			defer stopUpstream(ctx, in)
+			for x := range in {
+				if !isError(x) || o.flip_accept_error {
+					b, e, stop := o.testItem(ctx, x)
+					if stop && e != nil {
+						o.sendToFlow(ctx, e, out)
+					}
+					if stop || (e == nil && !b) {
+						return
+					}
+					if e != nil {
+						x = e
+					}
 				}
-			}
-			switch threading := o.threading; threading {
-			case ThreadingDefault:
-				if o.sample_interval > 0 {
-					sample_start = sample_start.Add(o.sample_interval)
+				if o.sendToFlow(ctx, x, out) {
+					return
 				}
-				if tsop.opFunc(ctx, o, xv, out) {
-					end = true
+			}
+		},
+	}
+	return o
+}
+
+// SkipWhile suppress items emitted by an Observable while `func(x anytype) bool` is true for them,
+// and then emit all the others. Errors flow at once unless the function accepts errors
+func (parent *Observable) SkipWhile(f interface{}) (o *Observable) {
+	o = parent.newFilterObservable("SkipWhile")
+	o.setFilterFunc(f)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			skipping := true
+			for x := range in {
+				if skipping && (!isError(x) || o.flip_accept_error) {
+					b, e, stop := o.testItem(ctx, x)
+					if stop {
+						o.stopFlow(ctx, e, in, out)
+						return
+					}
+					switch {
+					case e != nil:
+						x = e
+					case b:
+						continue
+					default:
+						skipping = false
+					}
 				}
-			case ThreadingIO:
-				fallthrough
-			case ThreadingComputing:
-				wg.Add(1)
-				if o.sample_interval > 0 {
-					sample_start.Add(o.sample_interval)
+				if o.sendToFlow(ctx, x, out) {
+					return
 				}
+			}
+		},
+	}
+	return o
+}
 
-				go func() {
-					defer wg.Done()
-					if tsop.opFunc(ctx, o, xv, out) {
-						end = true
+// TakeUntil emit items emitted by an Observable until a second Observable emits an item or error,
+// and then stop the Observable
+func (parent *Observable) TakeUntil(other *Observable) (o *Observable) {
+	o = parent.newFilterObservable("TakeUntil")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			signal, cancel := signalOf(ctx, other)
+			defer cancel()
+			for {
+				select {
+				case <-signal:
+					stopUpstream(ctx, in)
+					return
+				default:
+				}
+				select {
+				case x, ok := <-in:
+					if !ok || o.sendToFlow(ctx, x, out) {
+						return
 					}
-				}()
-			default:
-			}
-			if o.only_first {
-				break
+				case <-signal:
+				case <-ctx.Done():
+					return
+				}
 			}
-		}
-		if o.only_last && len(out_buf) > 0 {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				xv := reflect.ValueOf(out_buf[len(out_buf)-1])
-				tsop.opFunc(ctx, o, xv, out)
-			}()
-		}
-		if o.take != 0 || o.skip != 0 {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				var div int
-				if o.is_taking {
-					div = o.take
-				} else {
-					div = o.skip
-				}
-				new_in,err := judgeMode(o.is_taking, div, out_buf)
+		},
+	}
+	return o
+}
 
-				if err != nil {
-					o.sendToFlow(ctx, err, out)
-				} else {
-					xv := new_in
-					for _, val := range xv {
-						tsop.opFunc(ctx, o, reflect.ValueOf(val), out)
+// SkipUntil suppress items emitted by an Observable until a second Observable emits an item or error
+func (parent *Observable) SkipUntil(other *Observable) (o *Observable) {
+	o = parent.newFilterObservable("SkipUntil")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			signal, cancel := signalOf(ctx, other)
+			defer cancel()
+			for {
+				select {
+				case x, ok := <-in:
+					if !ok {
+						return
+					}
+					if signal != nil {
+						select {
+						case <-signal:
+							signal = nil
+						default:
+						}
 					}
+					if signal != nil && !isError(x) {
+						continue
+					}
+					if o.sendToFlow(ctx, x, out) {
+						return
+					}
+				case <-signal:
+					signal = nil
+				case <-ctx.Done():
+					return
 				}
-			}()
-		}
-		if o.element_at != 0 {
-			if o.element_at < 0 || o.element_at > len(out_buf) {
-				o.sendToFlow(ctx, ErrIndex, out)
-			} else {
-				xv := reflect.ValueOf(out_buf[o.element_at-1])
-				tsop.opFunc(ctx, o, xv, out)
 			}
+		},
+	}
+	return o
+}
+
+// signalOf returns a channel closed when the Observable emits its first item or error.
+// cancel stops the Observable
+func signalOf(ctx context.Context, ob *Observable) (signal chan struct{}, cancel context.CancelFunc) {
+	ctx, cancel = context.WithCancel(ctx)
+	signal = make(chan struct{})
+	go func() {
+		if _, ok := firstItem(ctx, ob); ok && ctx.Err() == nil {
+			close(signal)
 		}
-		wg.Wait()
-		if (o.only_last || o.only_first) && len(out_buf) == 0 && !o.flip_accept_error  {
-			o.sendToFlow(ctx, ErrInput, out)
-		}
-		o.closeFlow(out)
 	}()
+	return
 }
 
-// If isTake is true, then switch to Take mode, otherwise switch to Skip mode
-func judgeMode(isTake bool, division int, in []interface{}) ([]interface{}, error) {
-	fmt.Println(in)
-	if (isTake && division > 0) || (!isTake && division < 0) {
-		if !isTake {
-			division = len(in) + division
-		}
-		if division >= len(in) || division <= 0{
-			return nil, ErrIndex
-		}
-		return in[:division], nil
+// check f with `func(x anytype) bool`
+func (o *Observable) setFilterFunc(f interface{}) {
+	fv := reflect.ValueOf(f)
+	inType := []reflect.Type{typeAny}
+	outType := []reflect.Type{typeBool}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b {
+		panic(ErrFuncFlip)
 	}
+	o.flip_accept_error = checkFuncAcceptError(fv)
+	o.flip_sup_ctx = ctx_sup
+	o.flip = fv.Interface()
+}
 
-	if (isTake && division < 0) || (!isTake && division > 0) {
-		if isTake {
-			division = len(in) + division
-		}
-		if division >= len(in) || division <= 0{
-			return nil, ErrIndex
-		}
-		return in[division:], nil
+// test an item by the filter function, skipped items are false
+func (o *Observable) testItem(ctx context.Context, x interface{}) (b bool, e error, stop bool) {
+	rs, skip, stop, e := o.callFlip(ctx, reflect.ValueOf(x))
+	if skip || stop || e != nil {
+		return
+	}
+	return rs[0].Bool(), nil, false
+}
+
+// Throttle emit the first item emitted by an Observable, then ignore the subsequent items
+// during the timespan. It is also known as ThrottleFirst
+func (parent *Observable) Throttle(timespan time.Duration) (o *Observable) {
+	o = parent.newFilterObservable("Throttle")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			clk := o.getScheduler()
+			var open time.Time // items are accepted from this time
+
+			for x := range in {
+				if e, ok := x.(error); ok {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				now := clk.Now()
+				if now.Before(open) {
+					continue
+				}
+				open = now.Add(timespan)
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+		},
 	}
-	return nil, ErrIndex
+	return o
+}
+
+// AuditTime start a timer when an Observable emits an item while no timer runs, and emit the most recent item
+// when the timer fires. So it emits at most one item per timespan
+func (parent *Observable) AuditTime(timespan time.Duration) (o *Observable) {
+	o = parent.newFilterObservable("AuditTime")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			clk := o.getScheduler()
+			var timer SchedulerTimer
+			var fire <-chan time.Time
+			var latest interface{}
+			defer func() {
+				if timer != nil {
+					timer.Stop()
+				}
+			}()
+
+			for in != nil || fire != nil {
+				select {
+				case x, ok := <-in:
+					if !ok {
+						// the pending item is emitted when the running timer fires
+						in = nil
+						continue
+					}
+					if e, ok := x.(error); ok {
+						if o.sendToFlow(ctx, e, out) {
+							return
+						}
+						continue
+					}
+					latest = x
+					if fire == nil {
+						timer = clk.NewTimer(timespan)
+						fire = timer.C()
+					}
+				case <-fire:
+					fire = nil
+					if o.sendToFlow(ctx, latest, out) {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		},
+	}
+	return o
 }
@@ -1,6 +1,8 @@
 package rxgo
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,13 +12,31 @@ import (
 func TestDebounce(t *testing.T) {
 	res := []int{}
 	ob := Just(100, 200, 300, 400).Map(func(x int) int {
-		time.Sleep(20 * time.Millisecond)
 		return 2 * x
 	}).Debounce(30 * time.Millisecond)
 	ob.Subscribe(func(x int) {
 		res = append(res, x)
 	})
-	assert.Equal(t, []int{}, res, "Debounce Test Error!")
+	assert.Equal(t, []int{800}, res, "Debounce Test Error!")
+}
+
+func TestDebounceOnScheduler(t *testing.T) {
+	s := NewTestScheduler()
+	ch := make(chan int)
+	res, done := subscribeOnScheduler(From(ch).Debounce(30*time.Millisecond), s)
+
+	ch <- 1
+	s.Advance(10 * time.Millisecond)
+	ch <- 2
+	s.Advance(10 * time.Millisecond)
+	ch <- 3
+	s.Advance(40 * time.Millisecond)
+	ch <- 4
+	s.Advance(20 * time.Millisecond)
+	ch <- 5
+	close(ch)
+	<-done
+	assert.Equal(t, []int{3, 5}, *res, "Debounce Test Error!")
 }
 
 func TestDistinct(t *testing.T) {
@@ -64,15 +84,79 @@ func TestLast(t *testing.T) {
 	assert.Equal(t, []int{60}, res, "Last Test Error!")
 }
 
-func TestSample(t *testing.T) {
+func TestFirstLastEmpty(t *testing.T) {
+	for _, ob := range []*Observable{Empty().First(), Empty().Last()} {
+		var errs []error
+		ob.Subscribe(ObserverMonitor{
+			Error: func(e error) {
+				errs = append(errs, e)
+			},
+		})
+		assert.Equal(t, []error{ErrNoItem}, errs, ob.Name+" Empty Test Error!")
+	}
+}
+
+func TestFirstEndless(t *testing.T) {
+	stopped := make(chan struct{})
 	res := []int{}
-	Just(1, 2, 3, 4, 3, 1, 2, 4, 3).Map(func(x int) int {
-		time.Sleep(20 * time.Millisecond)
-		return 2 * x
-	}).Sample(15 * time.Millisecond).Subscribe(func(x int) {
+	endlessSource(stopped).First().Subscribe(func(x int) {
 		res = append(res, x)
 	})
-	assert.Equal(t, []int{2, 4, 6, 8, 6, 2, 4, 8, 6}, res, "Sample Test Error!")
+	<-stopped
+	assert.Equal(t, []int{0}, res, "First Endless Test Error!")
+}
+
+func TestSampleOnScheduler(t *testing.T) {
+	s := NewTestScheduler()
+	ch := make(chan int)
+	res, done := subscribeOnScheduler(From(ch).Sample(20*time.Millisecond), s)
+
+	ch <- 1
+	s.Advance(5 * time.Millisecond)
+	ch <- 2
+	s.Advance(20 * time.Millisecond)
+	s.Advance(20 * time.Millisecond)
+	ch <- 3
+	s.Advance(20 * time.Millisecond)
+	ch <- 4
+	close(ch)
+	<-done
+	assert.Equal(t, []int{2, 3}, *res, "Sample Test Error!")
+}
+
+func TestThrottleOnScheduler(t *testing.T) {
+	s := NewTestScheduler()
+	ch := make(chan int)
+	res, done := subscribeOnScheduler(From(ch).Throttle(20*time.Millisecond), s)
+
+	ch <- 1
+	s.Advance(5 * time.Millisecond)
+	ch <- 2
+	s.Advance(20 * time.Millisecond)
+	ch <- 3
+	s.Advance(10 * time.Millisecond)
+	ch <- 4
+	close(ch)
+	<-done
+	assert.Equal(t, []int{1, 3}, *res, "Throttle Test Error!")
+}
+
+func TestAuditTimeOnScheduler(t *testing.T) {
+	s := NewTestScheduler()
+	ch := make(chan int)
+	res, done := subscribeOnScheduler(From(ch).AuditTime(20*time.Millisecond), s)
+
+	ch <- 1
+	s.Advance(5 * time.Millisecond)
+	ch <- 2
+	s.Advance(20 * time.Millisecond)
+	ch <- 3
+	s.Advance(5 * time.Millisecond)
+	ch <- 4
+	close(ch)
+	s.Advance(20 * time.Millisecond)
+	<-done
+	assert.Equal(t, []int{2, 4}, *res, "AuditTime Test Error!")
 }
 
 func TestSkip(t *testing.T) {
@@ -118,3 +202,190 @@ func TestTakeLast(t *testing.T) {
 	})
 	assert.Equal(t, []int{8, 10, 12, 14}, res, "TakeLast Test Error!")
 }
+
+// an endless source, stopped is closed when it returns
+func endlessSource(stopped chan struct{}) *Observable {
+	return Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		defer close(stopped)
+		for i := 0; !send(i); i++ {
+		}
+	})
+}
+
+func TestTakeEndless(t *testing.T) {
+	stopped := make(chan struct{})
+	res := []int{}
+	endlessSource(stopped).Map(func(x int) int {
+		return 2 * x
+	}).Take(3).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{0, 2, 4}, res, "Take Test Error!")
+	<-stopped
+
+	res = []int{}
+	Just(1, 2).Take(5).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{1, 2}, res, "Take Test Error!")
+}
+
+func TestElementAtEndless(t *testing.T) {
+	stopped := make(chan struct{})
+	res := []int{}
+	endlessSource(stopped).ElementAt(3).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{2}, res, "ElementAt Test Error!")
+	<-stopped
+
+	var err error
+	Just(1, 2).ElementAt(3).Subscribe(ObserverMonitor{
+		Error: func(e error) {
+			err = e
+		},
+	})
+	assert.Equal(t, ErrIndex, err, "ElementAt Test Error!")
+}
+
+func TestSkipLastStreaming(t *testing.T) {
+	ch := make(chan int)
+	res := make(chan int, 10)
+	done := make(chan struct{})
+	go func() {
+		From(ch).SkipLast(2).Subscribe(func(x int) {
+			res <- x
+		})
+		close(done)
+	}()
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	assert.Equal(t, 1, <-res, "SkipLast should emit before completion")
+	ch <- 4
+	assert.Equal(t, 2, <-res, "SkipLast Test Error!")
+	close(ch)
+	<-done
+	assert.Equal(t, 0, len(res), "SkipLast Test Error!")
+}
+
+func TestTakeWhileSkipWhile(t *testing.T) {
+	stopped := make(chan struct{})
+	res := []int{}
+	endlessSource(stopped).TakeWhile(func(x int) bool {
+		return x < 4
+	}).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{0, 1, 2, 3}, res, "TakeWhile Test Error!")
+	<-stopped
+
+	res = []int{}
+	Just(1, 2, 5, 1, 6).SkipWhile(func(x int) bool {
+		return x < 3
+	}).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{5, 1, 6}, res, "SkipWhile Test Error!")
+}
+
+func TestTakeUntilSkipUntil(t *testing.T) {
+	s := NewTestScheduler()
+	ch := make(chan int)
+	res, done := subscribeOnScheduler(From(ch).TakeUntil(Timer(time.Second).SetScheduler(s)), s)
+	ch <- 1
+	ch <- 2
+	s.Advance(time.Second)
+	<-done
+	assert.Equal(t, []int{1, 2}, *res, "TakeUntil Test Error!")
+
+	ch = make(chan int)
+	res, done = subscribeOnScheduler(From(ch).SkipUntil(Timer(time.Second).SetScheduler(s)), s)
+	ch <- 1
+	ch <- 2
+	s.Advance(time.Second)
+	ch <- 3
+	close(ch)
+	<-done
+	assert.Equal(t, []int{3}, *res, "SkipUntil Test Error!")
+}
+
+// subscribe o with a test scheduler, items are collected into res until done
+func subscribeOnScheduler(o *Observable, s Scheduler) (res *[]int, done chan struct{}) {
+	res, done = &[]int{}, make(chan struct{})
+	o.SetScheduler(s)
+	go func() {
+		o.Subscribe(func(x int) {
+			*res = append(*res, x)
+		})
+		close(done)
+	}()
+	return
+}
+
+func TestDistinctUnhashable(t *testing.T) {
+	res := []interface{}{}
+	Just([]int{1}, 2, 2).Distinct().Subscribe(ObserverMonitor{
+		Next: func(x interface{}) {
+			res = append(res, x)
+		},
+		Error: func(e error) {
+			res = append(res, e)
+		},
+	})
+	assert.Equal(t, []interface{}{FlowableError{Err: ErrKeyNotComparable, Elements: []int{1}}, 2}, res, "Distinct Test Error!")
+}
+
+func TestDistinctByUnhashable(t *testing.T) {
+	type key struct{ v interface{} }
+	res := []interface{}{}
+	Just(1, 2, 3).DistinctBy(func(x int) key {
+		if x == 2 {
+			return key{[]int{x}}
+		}
+		return key{x}
+	}).Subscribe(ObserverMonitor{
+		Next: func(x interface{}) {
+			res = append(res, x)
+		},
+		Error: func(e error) {
+			res = append(res, e)
+		},
+	})
+	assert.Equal(t, []interface{}{1, FlowableError{Err: ErrKeyNotComparable, Elements: 2}, 3}, res, "DistinctBy Test Error!")
+}
+
+func TestDistinctBy(t *testing.T) {
+	res := [][]int{}
+	Just([]int{1, 2}, []int{3}, []int{1, 2}, []int{4, 5}).DistinctBy(func(x []int) int {
+		return len(x)
+	}).Subscribe(func(x []int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, [][]int{{1, 2}, {3}}, res, "DistinctBy Test Error!")
+}
+
+func TestDistinctKeyMemory(t *testing.T) {
+	res := []int{}
+	Just(1, 2, 1, 3, 2, 1).Distinct().SetKeyMemory(2).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	// 2 is forgotten when 3 comes since 1 is seen again after 2, and then 1 is forgotten when 2 comes
+	assert.Equal(t, []int{1, 2, 3, 2, 1}, res, "Distinct Test Error!")
+}
+
+func TestDistinctUntilChanged(t *testing.T) {
+	res := [][]int{}
+	Just([]int{1}, []int{1}, []int{2}, []int{1}).DistinctUntilChanged().Subscribe(func(x []int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, [][]int{{1}, {2}, {1}}, res, "DistinctUntilChanged Test Error!")
+
+	words := []string{}
+	Just("a", "A", "b", "B", "a").DistinctUntilChangedFunc(func(a, b string) bool {
+		return strings.EqualFold(a, b)
+	}).Subscribe(func(x string) {
+		words = append(words, x)
+	})
+	assert.Equal(t, []string{"a", "b", "a"}, words, "DistinctUntilChangedFunc Test Error!")
+}
@@ -0,0 +1,208 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Flattening operators map each item to an inner Observable by `func(x anytype) *Observable`,
+// and emit the items of inner Observables in different strategies. Errors of the source flow at once
+// unless the function accepts errors, and errors of inner Observables flow as their items
+
+// FlatMapWithConcurrency is FlatMap merging at most maxConcurrency inner Observables at the same time,
+// the source is not read until one of them completes. maxConcurrency <= 0 means no limit
+func (parent *Observable) FlatMapWithConcurrency(f interface{}, maxConcurrency int) (o *Observable) {
+	o = parent.newTransformObservable("FlatMapWithConcurrency")
+	o.setFlatMapFunc(f)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			ictx, cancel := context.WithCancel(ctx)
+			var wg sync.WaitGroup
+			// stop the running ones if the flow ends early, they may be infinite
+			defer func() {
+				cancel()
+				wg.Wait()
+			}()
+			var sem chan struct{}
+			if maxConcurrency > 0 {
+				sem = make(chan struct{}, maxConcurrency)
+			}
+
+			for x := range in {
+				inner, e, stop := o.innerOf(ctx, x)
+				if stop {
+					o.stopFlow(ctx, e, in, out)
+					return
+				}
+				if e != nil {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				if inner == nil {
+					continue
+				}
+				if sem != nil {
+					select {
+					case sem <- struct{}{}:
+					case <-ctx.Done():
+						return
+					}
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					o.forwardFlow(ictx, inner, out)
+					if sem != nil {
+						<-sem
+					}
+				}()
+			}
+			// the running ones complete as usual
+			wg.Wait()
+		},
+	}
+	return o
+}
+
+// ConcatMap emits the items of inner Observables one by one in order of the source items,
+// an inner Observable is subscribed after the previous one completes
+func (parent *Observable) ConcatMap(f interface{}) (o *Observable) {
+	o = parent.FlatMapWithConcurrency(f, 1)
+	o.Name = "ConcatMap"
+	return o
+}
+
+// SwitchMap emits the items of the latest inner Observable only,
+// the previous inner Observable is unsubscribed when a new source item arrives
+func (parent *Observable) SwitchMap(f interface{}) (o *Observable) {
+	o = parent.newTransformObservable("SwitchMap")
+	o.setFlatMapFunc(f)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			cancel := context.CancelFunc(func() {})
+			done := make(chan struct{})
+			close(done)
+			// stop the running one if the flow ends early, it may be infinite
+			defer func() {
+				cancel()
+				<-done
+			}()
+
+			for x := range in {
+				inner, e, stop := o.innerOf(ctx, x)
+				if stop {
+					o.stopFlow(ctx, e, in, out)
+					return
+				}
+				if e != nil {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				// the previous one must stop before the next one emits
+				cancel()
+				<-done
+				if inner == nil {
+					continue
+				}
+				ictx, c := context.WithCancel(ctx)
+				cancel, done = c, make(chan struct{})
+				go func(done chan struct{}) {
+					defer close(done)
+					o.forwardFlow(ictx, inner, out)
+				}(done)
+			}
+			// the last one completes as usual
+			<-done
+		},
+	}
+	return o
+}
+
+// ExhaustMap emits the items of an inner Observable until it completes, and ignores
+// the source items arriving during that time, for which the function is not called.
+// Source errors arriving during that time flow at once
+func (parent *Observable) ExhaustMap(f interface{}) (o *Observable) {
+	o = parent.newTransformObservable("ExhaustMap")
+	o.setFlatMapFunc(f)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			ictx, cancel := context.WithCancel(ctx)
+			done := make(chan struct{})
+			close(done)
+			// stop the running one if the flow ends early, it may be infinite
+			defer func() {
+				cancel()
+				<-done
+			}()
+
+			for x := range in {
+				select {
+				case <-done:
+				default:
+					if isError(x) && o.sendToFlow(ctx, x, out) {
+						return
+					}
+					continue
+				}
+				inner, e, stop := o.innerOf(ctx, x)
+				if stop {
+					o.stopFlow(ctx, e, in, out)
+					return
+				}
+				if e != nil {
+					if o.sendToFlow(ctx, e, out) {
+						return
+					}
+					continue
+				}
+				if inner == nil {
+					continue
+				}
+				done = make(chan struct{})
+				go func(done chan struct{}) {
+					defer close(done)
+					o.forwardFlow(ictx, inner, out)
+				}(done)
+			}
+			// the last one completes as usual
+			<-done
+		},
+	}
+	return o
+}
+
+// check f with `func(x anytype) *Observable`
+func (o *Observable) setFlatMapFunc(f interface{}) {
+	fv := reflect.ValueOf(f)
+	inType := []reflect.Type{typeAny}
+	outType := []reflect.Type{typeObservable}
+	b, ctx_sup := checkFuncUpcast(fv, inType, outType, true)
+	if !b {
+		panic(ErrFuncFlip)
+	}
+	o.flip_accept_error = checkFuncAcceptError(fv)
+	o.flip_sup_ctx = ctx_sup
+	o.flip = fv.Interface()
+}
+
+// map an item to its inner Observable, an error not accepted by the function is returned as e
+func (o *Observable) innerOf(ctx context.Context, x interface{}) (inner *Observable, e error, stop bool) {
+	if e, ok := x.(error); ok && !o.flip_accept_error {
+		return nil, e, false
+	}
+	rs, skip, stop, e := o.callFlip(ctx, reflect.ValueOf(x))
+	if skip || stop || e != nil {
+		return
+	}
+	inner, _ = rs[0].Interface().(*Observable)
+	return
+}
@@ -0,0 +1,143 @@
+package rxgo_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// emit 1 at 0ms, 2 at 10ms, 3 at 40ms, each mapped to an inner Observable emitting it at 20ms later
+func flattenOnScheduler(flatten func(o *rxgo.Observable, f interface{}) *rxgo.Observable) []interface{} {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	f := func(x int) *rxgo.Observable {
+		return rxgo.Timer(20 * time.Millisecond).SetScheduler(s).Map(func(int) int {
+			return x
+		})
+	}
+	done := make(chan []interface{})
+	go func() {
+		done <- collect(flatten(rxgo.From(ch), f))
+	}()
+	ch <- 1
+	s.Advance(10 * time.Millisecond)
+	ch <- 2
+	s.Advance(30 * time.Millisecond)
+	ch <- 3
+	s.Advance(30 * time.Millisecond)
+	close(ch)
+	return <-done
+}
+
+func TestSwitchMap(t *testing.T) {
+	res := flattenOnScheduler(func(o *rxgo.Observable, f interface{}) *rxgo.Observable {
+		return o.SwitchMap(f)
+	})
+	assert.Equal(t, []interface{}{2, 3}, res, "SwitchMap Test Error!")
+}
+
+// an inner Observable emitting 0, 1, 2, ... until it is stopped
+func endlessInner(stopped chan struct{}) *rxgo.Observable {
+	return rxgo.Generator(func(ctx context.Context, send func(x interface{}) bool) {
+		defer close(stopped)
+		for i := 0; !send(i); i++ {
+		}
+	})
+}
+
+func TestSwitchMapInfiniteInner(t *testing.T) {
+	stopped := make(chan struct{})
+	res := make(chan []interface{})
+	go func() {
+		res <- collect(rxgo.Just(1).SwitchMap(func(int) *rxgo.Observable {
+			return endlessInner(stopped)
+		}).Take(3))
+	}()
+	select {
+	case r := <-res:
+		assert.Equal(t, []interface{}{0, 1, 2}, r, "SwitchMap Test Error!")
+		<-stopped
+	case <-time.After(time.Second):
+		t.Fatal("SwitchMap should stop the infinite inner Observable")
+	}
+
+	// the flattening operators stop by themselves while the inner one is running
+	flattens := map[string]func(o *rxgo.Observable, f interface{}) *rxgo.Observable{
+		"SwitchMap": (*rxgo.Observable).SwitchMap,
+		"FlatMapWithConcurrency": func(o *rxgo.Observable, f interface{}) *rxgo.Observable {
+			return o.FlatMapWithConcurrency(f, 0)
+		},
+	}
+	for name, flatten := range flattens {
+		stopped := make(chan struct{})
+		go func() {
+			res <- collect(flatten(rxgo.Just(1, 2), func(x int) *rxgo.Observable {
+				if x == 2 {
+					panic("oops")
+				}
+				return endlessInner(stopped)
+			}).SetPanicPolicy(rxgo.PanicStop).Count())
+		}()
+		select {
+		case <-res:
+			<-stopped
+		case <-time.After(time.Second):
+			t.Fatal(name + " should stop the infinite inner Observable")
+		}
+	}
+}
+
+func TestExhaustMap(t *testing.T) {
+	res := flattenOnScheduler(func(o *rxgo.Observable, f interface{}) *rxgo.Observable {
+		return o.ExhaustMap(f)
+	})
+	assert.Equal(t, []interface{}{1, 3}, res, "ExhaustMap Test Error!")
+}
+
+func TestConcatMap(t *testing.T) {
+	res := flattenOnScheduler(func(o *rxgo.Observable, f interface{}) *rxgo.Observable {
+		return o.ConcatMap(f)
+	})
+	assert.Equal(t, []interface{}{1, 2, 3}, res, "ConcatMap Test Error!")
+
+	res = collect(rxgo.Range(0, 20).ConcatMap(func(x int) *rxgo.Observable {
+		return rxgo.Just(x, x)
+	}).Count())
+	assert.Equal(t, []interface{}{40}, res, "ConcatMap Count Test Error!")
+}
+
+func TestFlatMapWithConcurrency(t *testing.T) {
+	var running, most int32
+	res := []int{}
+	rxgo.Range(0, 20).FlatMapWithConcurrency(func(x int) *rxgo.Observable {
+		return rxgo.Just(x).Map(func(x int) int {
+			n := atomic.AddInt32(&running, 1)
+			for m := atomic.LoadInt32(&most); n > m && !atomic.CompareAndSwapInt32(&most, m, n); m = atomic.LoadInt32(&most) {
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return x
+		})
+	}, 3).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+
+	sort.Ints(res)
+	assert.Equal(t, 20, len(res), "FlatMapWithConcurrency lost items")
+	assert.Equal(t, 19, res[19], "FlatMapWithConcurrency Test Error!")
+	assert.True(t, most <= 3, "FlatMapWithConcurrency runs too many inner Observables")
+}
+
+func TestFlattenErrors(t *testing.T) {
+	e1, e2 := errors.New("source"), errors.New("inner")
+	res := collect(rxgo.Just(e1, 1, 2).ConcatMap(func(x int) *rxgo.Observable {
+		return rxgo.Just(x, e2)
+	}))
+	assert.Equal(t, []interface{}{e1, 1, e2, 2, e2}, res, "Flatten Errors Test Error!")
+}
@@ -7,6 +7,7 @@ package rxgo
 import (
 	"context"
 	"reflect"
+	"time"
 )
 
 // source node implementation of streamOperator
@@ -42,7 +43,13 @@ var sourceSource = sourceOperater{func(ctx context.Context, o *Observable, out c
 		endSignal = o.sendToFlow(ctx, x, out)
 		return
 	}
-	sf(ctx, send)
+	// the function ends after it panics, whatever the panic policy is
+	_, _, e := o.userFuncDo(nil, func() {
+		sf(ctx, send)
+	})
+	if e != nil {
+		o.sendToFlow(ctx, e, out)
+	}
 	return true
 }}
 
@@ -74,10 +81,13 @@ var startSource = sourceOperater{func(ctx context.Context, o *Observable, out ch
 	}
 
 	for end := false; !end; {
-		rs, skip, stop, e := userFuncCall(fv, params)
+		rs, skip, stop, e := o.userFuncCall(ctx, fv, params)
 
 		var item interface{}
 		if stop {
+			if e != nil {
+				o.sendToFlow(ctx, e, out)
+			}
 			return true
 		}
 		if skip {
@@ -200,13 +210,7 @@ func From(items interface{}) *Observable {
 		o := newGeneratorObservable("From *Observable")
 
 		o.flip = func(ctx context.Context, out chan interface{}) {
-			ro := v.Interface().(*Observable)
-			for ; ro.next != nil; ro = ro.next {
-			}
-			ro.mu.Lock()
-			ro.connect(ctx)
-			ch := ro.outflow
-			ro.mu.Unlock()
+			ch := v.Interface().(*Observable).connectFlow(ctx)
 			for item := range ch {
 				if b := o.sendToFlow(ctx, item, out); b {
 					return
@@ -258,6 +262,113 @@ func Throw(e error) *Observable {
 	return o
 }
 
+var intervalSource = rangeSource
+var timerSource = rangeSource
+var repeatSource = rangeSource
+
+// Interval creates an Observable that emits a sequence of integers spaced by a given time interval.
+// It never terminates until the subscriber unsubscribes
+func Interval(d time.Duration) *Observable {
+	o := newGeneratorObservable("Interval")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		ticker := o.getScheduler().NewTicker(d)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			select {
+			case <-ticker.C():
+			case <-ctx.Done():
+				return
+			}
+			if b := o.sendToFlow(ctx, i, out); b {
+				return
+			}
+		}
+	}
+	o.operator = intervalSource
+	return o
+}
+
+// Timer creates an Observable that emits a particular item (zero) after a given delay, and then terminates
+func Timer(delay time.Duration) *Observable {
+	o := newGeneratorObservable("Timer")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		timer := o.getScheduler().NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+			o.sendToFlow(ctx, 0, out)
+		case <-ctx.Done():
+		}
+	}
+	o.operator = timerSource
+	return o
+}
+
+// Repeat creates an Observable that emits the sequence of items emitted by the source Observable repeatedly.
+// The source is subscribed n times in total, or endless when n is negative
+func (parent *Observable) Repeat(n int) *Observable {
+	if n == 0 {
+		return Empty()
+	}
+	o := parent.RepeatWhen(func(count int) *Observable {
+		if n > 0 && count >= n {
+			return nil
+		}
+		return Just(count)
+	})
+	o.Name = "Repeat"
+	return o
+}
+
+// RepeatWhen creates an Observable that resubscribes the source Observable when it completes.
+// After each completion, the notifier is called with the number of completed subscriptions,
+// and the source is resubscribed once the returned Observable emits an item.
+// It terminates if the notifier returns nil or an Observable which completes without any item
+func (parent *Observable) RepeatWhen(notifier func(count int) *Observable) *Observable {
+	o := newGeneratorObservable("RepeatWhen")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		for count := 1; ; count++ {
+			if b := o.forwardFlow(ctx, parent, out); b {
+				return
+			}
+			if b := o.waitSignal(ctx, notifier(count), out); b {
+				return
+			}
+		}
+	}
+	o.operator = repeatSource
+	return o
+}
+
+// wait the first item emitted by the signal Observable. an error emitted by it flows to out.
+// It returns end if the signal is nil, or completes without any item
+func (o *Observable) waitSignal(ctx context.Context, signal *Observable, out chan interface{}) (end bool) {
+	if signal == nil {
+		return true
+	}
+	item, ok := firstItem(ctx, signal)
+	if !ok {
+		return true
+	}
+	if e, ok := item.(error); ok {
+		o.sendToFlow(ctx, e, out)
+		return true
+	}
+	return false
+}
+
+// get the first item of the Observable, ok is false if it completes without any item
+func firstItem(ctx context.Context, ob *Observable) (item interface{}, ok bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	item, ok = <-ob.connectFlow(ctx)
+	return
+}
+
 func newGeneratorObservable(name string) (o *Observable) {
 	//new Observable
 	o = newObservable()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
 	"testing"
 	"time"
 
@@ -186,3 +187,164 @@ func TestNeverWithCancel(t *testing.T) {
 
 	rxgo.Never().Subscribe(oberver)
 }
+
+// wait all goroutines of flows exited
+func checkGoroutines(t *testing.T, before int) {
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, runtime.NumGoroutine() <= before, "goroutines leaked")
+}
+
+func TestIntervalWithCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	res := []int{}
+	var oberver = rxgo.ObserverMonitor{}
+	oberver.Next = func(x interface{}) {
+		res = append(res, x.(int))
+		if len(res) == 3 {
+			oberver.Unsubscribe()
+		}
+	}
+	oberver.Context = func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		oberver.CancelObservables = cancel
+		return ctx
+	}
+
+	rxgo.Interval(time.Millisecond).Subscribe(oberver)
+	assert.False(t, len(res) > 4, "Interval cancel failure!")
+	assert.Equal(t, []int{0, 1, 2}, res[:3], "Interval Test Error!")
+	checkGoroutines(t, before)
+}
+
+func TestTimer(t *testing.T) {
+	res := []int{}
+	start := time.Now()
+	rxgo.Timer(10 * time.Millisecond).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.True(t, time.Since(start) >= 10*time.Millisecond, "Timer emits too early")
+	assert.Equal(t, []int{0}, res, "Timer Test Error!")
+}
+
+func TestTimerWithCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	var oberver = rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			t.Errorf("Timer Test expect %v ", "Nothng")
+		},
+	}
+	oberver.Context = func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		oberver.CancelObservables = cancel
+		return ctx
+	}
+	oberver.AfterConnected = func() {
+		oberver.Unsubscribe()
+	}
+
+	rxgo.Timer(time.Hour).Subscribe(oberver)
+	checkGoroutines(t, before)
+}
+
+func TestRepeat(t *testing.T) {
+	res := []int{}
+	rxgo.Just(1, 2).Map(func(x int) int {
+		return x * 10
+	}).Repeat(3).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{10, 20, 10, 20, 10, 20}, res, "Repeat Test Error!")
+
+	res = []int{}
+	rxgo.Just(1).Repeat(0).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{}, res, "Repeat zero Test Error!")
+}
+
+func TestRepeatWhen(t *testing.T) {
+	counts := []int{}
+	res := []int{}
+	rxgo.Just(1).RepeatWhen(func(count int) *rxgo.Observable {
+		counts = append(counts, count)
+		if count < 3 {
+			return rxgo.Timer(time.Millisecond)
+		}
+		return rxgo.Empty()
+	}).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []int{1, 1, 1}, res, "RepeatWhen Test Error!")
+	assert.Equal(t, []int{1, 2, 3}, counts, "RepeatWhen count Error!")
+}
+
+func TestRepeatWithCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	res := []int{}
+	var oberver = rxgo.ObserverMonitor{}
+	oberver.Next = func(x interface{}) {
+		res = append(res, x.(int))
+		if len(res) == 5 {
+			oberver.Unsubscribe()
+		}
+	}
+	oberver.Context = func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		oberver.CancelObservables = cancel
+		return ctx
+	}
+
+	rxgo.Just(1, 2).Repeat(-1).Subscribe(oberver)
+	assert.True(t, len(res) >= 5, "Repeat cancel failure!")
+	checkGoroutines(t, before)
+}
+
+func TestIntervalOnScheduler(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	res := make(chan int, 10)
+	var oberver = rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			res <- x.(int)
+		},
+	}
+	oberver.Context = func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		oberver.CancelObservables = cancel
+		return ctx
+	}
+	done := make(chan struct{})
+	go func() {
+		rxgo.Interval(time.Hour).SetScheduler(s).Subscribe(oberver)
+		close(done)
+	}()
+
+	s.Advance(30 * time.Minute)
+	assert.Equal(t, 0, len(res), "Interval emits too early")
+	s.Advance(150 * time.Minute)
+	assert.Equal(t, []int{0, 1, 2}, []int{<-res, <-res, <-res}, "Interval Test Error!")
+
+	oberver.Unsubscribe()
+	<-done
+	assert.Equal(t, 0, s.Pending(), "Interval ticker not stopped")
+}
+
+func TestTimerOnScheduler(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	res := []int{}
+	done := make(chan struct{})
+	go func() {
+		rxgo.Timer(24 * time.Hour).SetScheduler(s).Subscribe(func(x int) {
+			res = append(res, x)
+		})
+		close(done)
+	}()
+
+	s.Advance(24 * time.Hour)
+	<-done
+	assert.Equal(t, []int{0}, res, "Timer Test Error!")
+}
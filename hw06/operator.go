@@ -0,0 +1,56 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+// Operator chains operators to an Observable and returns the last one, so that
+// several operators can be packaged as one reusable unit, such as
+//
+//	func Dedupe() rxgo.Operator {
+//		return rxgo.NewOperator("Dedupe", func(o *rxgo.Observable) *rxgo.Observable {
+//			return o.DistinctUntilChanged()
+//		})
+//	}
+type Operator func(o *Observable) *Observable
+
+// Pipe applies the operators to the Observable in order, and returns the last Observable
+func (o *Observable) Pipe(ops ...Operator) *Observable {
+	for _, op := range ops {
+		if op != nil {
+			o = op(o)
+		}
+	}
+	return o
+}
+
+// Compose combines the operators into one, which applies them in order
+func Compose(ops ...Operator) Operator {
+	return func(o *Observable) *Observable {
+		return o.Pipe(ops...)
+	}
+}
+
+// NewOperator names the Observables chained by op, so that they show up with the name in debug, trace and metrics.
+// The only Observable is named as name, and more Observables are named as name/their names
+func NewOperator(name string, op Operator) Operator {
+	return func(o *Observable) *Observable {
+		last := op(o)
+		var created []*Observable
+		for po := last; po != nil && po != o; po = po.pred {
+			created = append(created, po)
+		}
+		for _, po := range created {
+			if len(created) == 1 {
+				po.Name = name
+			} else {
+				po.Name = name + "/" + po.Name
+			}
+			// renew the debug observer created with the old name
+			if _, ok := po.debug.(InnerObserver); ok {
+				po.debug = InnerObserver{po.Name + " debug "}
+			}
+		}
+		return last
+	}
+}
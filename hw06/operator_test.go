@@ -0,0 +1,58 @@
+package rxgo_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipe(t *testing.T) {
+	res := collect(rxgo.Just(1, 2, 3).Pipe(
+		func(o *rxgo.Observable) *rxgo.Observable {
+			return o.Map(func(x int) int { return 2 * x })
+		},
+		nil,
+		func(o *rxgo.Observable) *rxgo.Observable {
+			return o.Filter(func(x int) bool { return x > 2 })
+		},
+	))
+	assert.Equal(t, []interface{}{4, 6}, res, "Pipe Test Error!")
+}
+
+func TestNewOperator(t *testing.T) {
+	parseInts := rxgo.NewOperator("ParseInts", rxgo.Compose(
+		func(o *rxgo.Observable) *rxgo.Observable {
+			return o.Map(func(s string) int {
+				n, _ := strconv.Atoi(s)
+				return n
+			})
+		},
+		func(o *rxgo.Observable) *rxgo.Observable {
+			return o.Filter(func(x int) bool { return x >= 0 })
+		},
+	))
+	dedupe := rxgo.NewOperator("Dedupe", func(o *rxgo.Observable) *rxgo.Observable {
+		return o.DistinctUntilChanged()
+	})
+
+	var mu sync.Mutex
+	names := map[string]bool{}
+	tracer := rxgo.TracerFunc(func(ev rxgo.TraceEvent) {
+		mu.Lock()
+		names[ev.Name] = true
+		mu.Unlock()
+	})
+	o := rxgo.Just("1", "1", "-2", "3").SetTracer(tracer).Pipe(parseInts, dedupe)
+	assert.Equal(t, "Dedupe", o.Name, "NewOperator Name Test Error!")
+	res := collect(o)
+	assert.Equal(t, []interface{}{1, 3}, res, "NewOperator Test Error!")
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, name := range []string{"Just", "ParseInts/map", "ParseInts/filter", "Dedupe"} {
+		assert.True(t, names[name], "NewOperator Trace Name Test Error! "+name)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// a user function panics, it flows in FlowableError with the items passed to the function and the stack trace
+var ErrPanic = errors.New("User function panic!")
+
+// PanicPolicy decides what an Observable does when its user function panics.
+// The panic is recovered into a FlowableError with ErrPanic, so that it never crashes the program
+type PanicPolicy uint
+
+const (
+	PanicResume PanicPolicy = iota // emit the FlowableError and go on with the next item
+	PanicSkip                      // drop the item and go on with the next one
+	PanicStop                      // emit the FlowableError and stop the flow
+)
+
+// SetPanicPolicy set what the Observable does when its user function panics, PanicResume by default
+func (o *Observable) SetPanicPolicy(p PanicPolicy) *Observable {
+	o.panic_policy = p
+	return o
+}
+
+// call a user function of the Observable, and apply the panic policy to the recovered panic
+func (o *Observable) userFuncCall(ctx context.Context, fv reflect.Value, params []reflect.Value) (res []reflect.Value, skip, stop bool, eout error) {
+	res, skip, stop, eout = userFuncCall(fv, params)
+	skip, stop, eout = o.applyPanicPolicy(skip, stop, eout)
+	return
+}
+
+// call a user function which is not called by reflection, such as the function of Generator and TransformOp.
+// items are the items passed to it, the recovered panic is handled as userFuncCall
+func (o *Observable) userFuncDo(items interface{}, f func()) (skip, stop bool, eout error) {
+	defer func() {
+		if e := recover(); e != nil {
+			skip, stop, eout = o.applyPanicPolicy(userPanic(e, items))
+		}
+	}()
+
+	f()
+	return
+}
+
+// apply the panic policy to the result of a user function call. By PanicStop, stop is true with the error,
+// the caller sends it to its outflow and stops the upstream
+func (o *Observable) applyPanicPolicy(skip, stop bool, eout error) (bool, bool, error) {
+	fe, ok := eout.(FlowableError)
+	if !ok || !errors.Is(fe.Err, ErrPanic) {
+		return skip, stop, eout
+	}
+	switch o.panic_policy {
+	case PanicSkip:
+		return true, false, nil
+	case PanicStop:
+		return false, true, fe
+	}
+	return skip, stop, eout
+}
+
+// stop an operator whose user function stops the flow, the error stopping it flows at last if any
+func (o *Observable) stopFlow(ctx context.Context, e error, in chan interface{}, out chan interface{}) {
+	stopUpstream(ctx, in)
+	if e != nil {
+		o.sendToFlow(ctx, e, out)
+	}
+}
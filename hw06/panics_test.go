@@ -0,0 +1,121 @@
+package rxgo_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func panicAt(n int) func(x int) int {
+	return func(x int) int {
+		if x == n {
+			panic("boom")
+		}
+		return x
+	}
+}
+
+func TestPanicResume(t *testing.T) {
+	res := collect(rxgo.Just(1, 2, 3).Map(panicAt(2)))
+	assert.Equal(t, 3, len(res), "Panic Resume Test Error!")
+	assert.Equal(t, []interface{}{1, 3}, []interface{}{res[0], res[2]}, "Panic Resume Test Error!")
+
+	fe, ok := res[1].(rxgo.FlowableError)
+	assert.True(t, ok, "Panic Resume Test Error!")
+	assert.True(t, errors.Is(fe, rxgo.ErrPanic), "Panic Error Test Error!")
+	assert.True(t, strings.Contains(fe.Error(), "boom"), "Panic Value Test Error!")
+	assert.Equal(t, 2, fe.Elements, "Panic Item Test Error!")
+	assert.True(t, strings.Contains(fe.Stack, "panicAt"), "Panic Stack Test Error!")
+}
+
+func TestPanicSkip(t *testing.T) {
+	res := collect(rxgo.Just(1, 2, 3).Filter(func(x int) bool {
+		if x == 2 {
+			panic("boom")
+		}
+		return true
+	}).SetPanicPolicy(rxgo.PanicSkip))
+	assert.Equal(t, []interface{}{1, 3}, res, "Panic Skip Test Error!")
+}
+
+func TestPanicStop(t *testing.T) {
+	res := collect(rxgo.Just(1, 2, 3).FlatMap(func(x int) *rxgo.Observable {
+		if x == 2 {
+			panic("boom")
+		}
+		return rxgo.Just(x, x)
+	}).SetPanicPolicy(rxgo.PanicStop))
+	assert.Equal(t, 3, len(res), "Panic Stop Test Error!")
+	assert.Equal(t, []interface{}{1, 1}, res[:2], "Panic Stop Test Error!")
+	assert.True(t, errors.Is(res[2].(error), rxgo.ErrPanic), "Panic Stop Test Error!")
+}
+
+func TestPanicStart(t *testing.T) {
+	n := 0
+	res := collect(rxgo.Start(func() (int, bool) {
+		n++
+		if n == 2 {
+			panic("boom")
+		}
+		return n, n > 3
+	}))
+	assert.Equal(t, 3, len(res), "Panic Start Test Error!")
+	assert.Equal(t, []interface{}{1, 3}, []interface{}{res[0], res[2]}, "Panic Start Test Error!")
+	assert.Nil(t, res[1].(rxgo.FlowableError).Elements, "Panic Start Test Error!")
+}
+
+func TestPanicFold(t *testing.T) {
+	res := collect(rxgo.Just(1, 2, 3).Reduce(func(acc, x int) int {
+		if x == 2 {
+			panic("boom")
+		}
+		return acc + x
+	}).SetPanicPolicy(rxgo.PanicSkip))
+	assert.Equal(t, []interface{}{4}, res, "Panic Fold Test Error!")
+}
+
+func TestPanicGenerator(t *testing.T) {
+	res := collect(rxgo.Generator(func(ctx context.Context, send func(x interface{}) bool) {
+		send(1)
+		panic("boom")
+	}))
+	assert.Equal(t, 2, len(res), "Panic Generator Test Error!")
+	assert.Equal(t, 1, res[0], "Panic Generator Test Error!")
+	assert.True(t, errors.Is(res[1].(error), rxgo.ErrPanic), "Panic Generator Test Error!")
+}
+
+func TestPanicStopOrdered(t *testing.T) {
+	// the panicking item is slow, so that the items after it are served before it
+	res := collect(rxgo.Range(0, 100).Map(func(x int) int {
+		if x == 5 {
+			time.Sleep(10 * time.Millisecond)
+			panic("boom")
+		}
+		return x
+	}).SubscribeOn(rxgo.ThreadingComputing).SetPoolSize(4).SetOrdered(true).SetPanicPolicy(rxgo.PanicStop))
+	assert.Equal(t, 6, len(res), "Panic Stop should end with the error")
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, res[:5], "Panic Stop should keep the order")
+	assert.True(t, errors.Is(res[5].(error), rxgo.ErrPanic), "Panic Stop should end with the error")
+}
+
+func TestPanicStopUpstream(t *testing.T) {
+	stopped := make(chan struct{})
+	res := make(chan []interface{})
+	go func() {
+		res <- collect(endlessInner(stopped).Map(panicAt(3)).SetPanicPolicy(rxgo.PanicStop))
+	}()
+	select {
+	case r := <-res:
+		assert.Equal(t, 4, len(r), "Panic Stop Test Error!")
+		assert.Equal(t, []interface{}{0, 1, 2}, r[:3], "Panic Stop Test Error!")
+		assert.True(t, errors.Is(r[3].(error), rxgo.ErrPanic), "Panic Stop Test Error!")
+		<-stopped
+	case <-time.After(time.Second):
+		t.Fatal("Panic Stop should stop the upstream")
+	}
+}
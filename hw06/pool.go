@@ -0,0 +1,106 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// SetPoolSize set the number of goroutines serving items with ThreadingComputing.
+// It is runtime.GOMAXPROCS by default
+func (o *Observable) SetPoolSize(size uint) *Observable {
+	o.pool_size = size
+	return o
+}
+
+// SetOrdered make items served with ThreadingComputing flow out in the order they arrive,
+// as ConcatMap does. Otherwise an item flows out as soon as it is served
+func (o *Observable) SetOrdered(ordered bool) *Observable {
+	o.ordered = ordered
+	return o
+}
+
+// workerPool serves items of an Observable by a limited group of goroutines
+type workerPool struct {
+	tasks chan *poolTask
+	order chan *poolTask // ordered tasks in order of submission, nil if unordered
+	wg    sync.WaitGroup // workers and sequencer
+}
+
+type poolTask struct {
+	serve func(ctx context.Context, out chan interface{}) (end bool)
+	out   chan interface{}
+	end   bool // the task ends the flow, it is set before out is closed
+}
+
+// marks the context of ordered tasks, whose items flow to their own outflow
+type poolTaskKey struct{}
+
+func (o *Observable) newWorkerPool(ctx context.Context, out chan interface{}) *workerPool {
+	size := int(o.pool_size)
+	if size == 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	p := &workerPool{tasks: make(chan *poolTask)}
+	taskCtx := context.WithValue(ctx, poolTaskKey{}, true)
+
+	for i := 0; i < size; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for t := range p.tasks {
+				if t.out == nil {
+					t.serve(ctx, out)
+					continue
+				}
+				t.end = t.serve(taskCtx, t.out)
+				close(t.out)
+			}
+		}()
+	}
+
+	if o.ordered {
+		// the sequencer, served items of a task are hold until the tasks before it are done.
+		// Items of the tasks after the one ending the flow are dropped
+		p.order = make(chan *poolTask, size)
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			end := false
+			for t := range p.order {
+				for x := range t.out {
+					// keep draining after the end, so that workers never block
+					if !end {
+						end = o.sendToFlow(ctx, x, out)
+					}
+				}
+				end = end || t.end
+			}
+		}()
+	}
+	return p
+}
+
+// submit an item to the pool, it blocks until a worker is free
+// serve returns true if the item ends the flow
+func (p *workerPool) submit(serve func(ctx context.Context, out chan interface{}) (end bool)) {
+	t := &poolTask{serve: serve}
+	if p.order != nil {
+		t.out = make(chan interface{}, BufferLen)
+		p.order <- t
+	}
+	p.tasks <- t
+}
+
+// wait all submitted items served
+func (p *workerPool) wait() {
+	close(p.tasks)
+	if p.order != nil {
+		close(p.order)
+	}
+	p.wg.Wait()
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// Rate limiting operators hold items back or drop them to keep the rate of items. Errors are not limited,
+// they flow at once. Time runs on the scheduler of the Observable, so they can be tested with TestScheduler.
+// Dropped items are counted by Dropped and reported to the BackpressureMonitor set by SetMonitor
+
+// the arguments of a rate limiting operator must be positive
+var ErrLimitArgs = errors.New("Rate limit arguments are illegal!")
+
+// LimitMode decides what a rate limiting operator does with an item over the rate
+type LimitMode uint
+
+const (
+	LimitBlock LimitMode = iota // wait until the item is allowed, which holds back the upstream
+	LimitDrop                   // drop the item
+)
+
+// RateLimit emits at most n items in any timespan of per
+func (parent *Observable) RateLimit(n int, per time.Duration, mode LimitMode) (o *Observable) {
+	if n <= 0 || per <= 0 {
+		panic(ErrLimitArgs)
+	}
+	o = parent.newLimitObservable("RateLimit", mode, func() limiter {
+		return &windowLimiter{n: n, per: per}
+	})
+	return o
+}
+
+// Spread emits items at least the timespan apart
+func (parent *Observable) Spread(timespan time.Duration, mode LimitMode) (o *Observable) {
+	o = parent.RateLimit(1, timespan, mode)
+	o.Name = "Spread"
+	return o
+}
+
+// TokenBucket emits an item if it takes a token from the bucket, which holds at most burst tokens
+// and is refilled at rate tokens per second. The bucket is full when subscribed
+func (parent *Observable) TokenBucket(rate float64, burst int, mode LimitMode) (o *Observable) {
+	if rate <= 0 || burst <= 0 {
+		panic(ErrLimitArgs)
+	}
+	o = parent.newLimitObservable("TokenBucket", mode, func() limiter {
+		return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+	})
+	return o
+}
+
+// limiter decides when an item is allowed, it is used by one goroutine
+type limiter interface {
+	wait(now time.Time) time.Duration // time to wait before an item is allowed, 0 if it is allowed now
+	take(now time.Time)               // an item is allowed and emitted
+}
+
+// initialize a new Observable limiting items by the limiter created when connected
+func (parent *Observable) newLimitObservable(name string, mode LimitMode, newLimiter func() limiter) (o *Observable) {
+	o = parent.newFilterObservable(name)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			clk := o.getScheduler()
+			l := newLimiter()
+
+			for x := range in {
+				if isError(x) {
+					if o.sendToFlow(ctx, x, out) {
+						return
+					}
+					continue
+				}
+				now := clk.Now()
+				d := l.wait(now)
+				if d > 0 && mode == LimitDrop {
+					o.dropItem(x)
+					continue
+				}
+				for ; d > 0; d = l.wait(now) {
+					timer := clk.NewTimer(d)
+					select {
+					case <-timer.C():
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					}
+					now = clk.Now()
+				}
+				l.take(now)
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+		},
+	}
+	return o
+}
+
+// windowLimiter allows n items in any timespan of per by the times of the latest n items
+type windowLimiter struct {
+	n     int
+	per   time.Duration
+	times []time.Time
+}
+
+func (w *windowLimiter) wait(now time.Time) time.Duration {
+	if len(w.times) < w.n {
+		return 0
+	}
+	if d := w.times[0].Add(w.per).Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+func (w *windowLimiter) take(now time.Time) {
+	w.times = append(w.times, now)
+	if len(w.times) > w.n {
+		w.times = w.times[1:]
+	}
+}
+
+type tokenBucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) wait(now time.Time) time.Duration {
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) / b.rate * float64(time.Second)))
+}
+
+func (b *tokenBucket) take(now time.Time) {
+	b.tokens--
+}
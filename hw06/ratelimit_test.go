@@ -0,0 +1,71 @@
+package rxgo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// emit times of the items limited on a TestScheduler advanced steps times by 10ms
+func limitedMillis(o *rxgo.Observable, steps int) (items []interface{}, times []int64) {
+	s := rxgo.NewTestScheduler()
+	done := collectAsync(o.SetScheduler(s).Timestamp())
+	for i := 0; i < steps; i++ {
+		s.Advance(10 * time.Millisecond)
+	}
+	return timedMillis(<-done)
+}
+
+func TestRateLimit(t *testing.T) {
+	items, times := limitedMillis(rxgo.Range(1, 6).RateLimit(2, 10*time.Millisecond, rxgo.LimitBlock), 2)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, items, "RateLimit Test Error!")
+	assert.Equal(t, []int64{0, 0, 10, 10, 20}, times, "RateLimit Time Test Error!")
+
+	o := rxgo.Range(1, 6).RateLimit(2, time.Hour, rxgo.LimitDrop)
+	assert.Equal(t, []interface{}{1, 2}, collect(o), "RateLimit Drop Test Error!")
+	assert.Equal(t, uint64(3), o.Dropped(), "RateLimit Dropped Test Error!")
+}
+
+func TestSpread(t *testing.T) {
+	items, times := limitedMillis(rxgo.Range(1, 4).Spread(10*time.Millisecond, rxgo.LimitBlock), 2)
+	assert.Equal(t, []interface{}{1, 2, 3}, items, "Spread Test Error!")
+	assert.Equal(t, []int64{0, 10, 20}, times, "Spread Time Test Error!")
+
+	e := errors.New("oops")
+	res := collect(rxgo.Just(1, e, 2).Spread(time.Hour, rxgo.LimitDrop))
+	assert.Equal(t, []interface{}{1, e}, res, "Spread Drop Test Error!")
+}
+
+func TestTokenBucket(t *testing.T) {
+	items, times := limitedMillis(rxgo.Range(1, 6).TokenBucket(100, 2, rxgo.LimitBlock), 3)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, items, "TokenBucket Test Error!")
+	assert.Equal(t, []int64{0, 0, 10, 20, 30}, times, "TokenBucket Time Test Error!")
+}
+
+func TestTokenBucketDrop(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	o := rxgo.From(ch).TokenBucket(100, 2, rxgo.LimitDrop).SetScheduler(s)
+	done := collectAsync(o)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	s.Advance(10 * time.Millisecond)
+	ch <- 4
+	ch <- 5
+	close(ch)
+	assert.Equal(t, []interface{}{1, 2, 4}, <-done, "TokenBucket Drop Test Error!")
+	assert.Equal(t, uint64(2), o.Dropped(), "TokenBucket Dropped Test Error!")
+}
+
+func TestRateLimitArgs(t *testing.T) {
+	assert.PanicsWithValue(t, rxgo.ErrLimitArgs, func() {
+		rxgo.Just(1).RateLimit(0, time.Second, rxgo.LimitBlock)
+	})
+	assert.PanicsWithValue(t, rxgo.ErrLimitArgs, func() {
+		rxgo.Just(1).TokenBucket(0, 1, rxgo.LimitDrop)
+	})
+}
@@ -10,7 +10,7 @@ import (
 	"errors"
 	"reflect"
 	"sync"
-	"time"
+	"sync/atomic"
 )
 
 type ThreadModel uint
@@ -33,16 +33,24 @@ var ErrEoFlow = errors.New("End of Flow!")
 // if user function throw SkipItem, the Observeable will skip current item
 var ErrSkipItem = errors.New("Skip item!")
 
+// if the outflow is full, the Observable with BackpressureError will emit it and stop
+var ErrBufferOverflow = errors.New("Buffer overflow!")
+
 // Error that can flow to subscriber or user function which processes error as an input
 type FlowableError struct {
 	Err      error
 	Elements interface{}
+	Stack    string // stack trace of the panic with ErrPanic
 }
 
 func (e FlowableError) Error() string {
 	return e.Err.Error()
 }
 
+func (e FlowableError) Unwrap() error {
+	return e.Err
+}
+
 // Observer subscribes to an Observable. Then that observer reacts to whatever item or sequence of items the Observable emits.
 type Observer interface {
 	OnNext(x interface{})
@@ -66,6 +74,7 @@ type ObserverMonitor struct {
 	Context           func() context.Context // an observer context musit gived when observables before connected
 	AfterConnected    func()
 	CancelObservables context.CancelFunc
+	Dropped           func(x interface{}, count uint64) // item dropped by backpressure and the total count
 }
 
 func (o ObserverMonitor) OnNext(x interface{}) {
@@ -86,6 +95,12 @@ func (o ObserverMonitor) OnCompleted() {
 	}
 }
 
+func (o ObserverMonitor) OnDropped(x interface{}, count uint64) {
+	if o.Dropped != nil {
+		o.Dropped(x, count)
+	}
+}
+
 func (o ObserverMonitor) GetObserverContext() (c context.Context) {
 	if o.Context != nil {
 		return o.Context()
@@ -109,6 +124,23 @@ type streamOperator interface {
 	op(ctx context.Context, o *Observable)
 }
 
+// stream node implementation of streamOperator, the opFunc consumes the whole inflow
+// by itself. It is used by operators that hold states among items, such as time-based operators
+type flowOperator struct {
+	opFunc func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{})
+}
+
+func (fop flowOperator) op(ctx context.Context, o *Observable) {
+	// must hold defintion of flow resourcs here, such as chan etc., that is allocated when connected
+	in := o.inflow
+	out := o.outflow
+
+	go func() {
+		fop.opFunc(ctx, o, in, out)
+		o.closeFlow(out)
+	}()
+}
+
 //emit something
 type sourceFunc func(ctx context.Context, send func(x interface{}) (endSignal bool))
 
@@ -133,22 +165,25 @@ type Observable struct {
 	next *Observable
 	pred *Observable
 	// control model
-	threading ThreadModel //threading model. if this is root, it represents obseverOn model
-	buf_len   uint
+	threading    ThreadModel //threading model. if this is root, it represents obseverOn model
+	buf_len      uint
+	pool_size    uint                 // size of goroutine group with ThreadingComputing
+	ordered      bool                 // keep order of items served by goroutine group
+	backpressure BackpressureStrategy // what to do when the outflow is full
+	panic_policy PanicPolicy          // what to do when the user function panics
+	dropped      uint64               // number of items dropped by backpressure
+	key_memory   uint                 // max number of keys remembered by Distinct, 0 for unlimited
 	// utility vars
 	debug             Observer
-	flip_sup_ctx      bool //indicate that flip function use context as first paramter
-	flip_accept_error bool // indicate that flip function input's data is type interface{} or error
-
-	only_first		  bool
-	only_last		  bool
-	debounce_timespan time.Duration
-	only_distinct	  bool
-	sample_interval   time.Duration
-	element_at		  int
-	skip			  int
-	take			  int
-	is_taking		  bool
+	flip_sup_ctx      bool      //indicate that flip function use context as first paramter
+	flip_accept_error bool      // indicate that flip function input's data is type interface{} or error
+	scheduler         Scheduler // time source of time-based operators
+	tracer            Tracer    // receive trace events of this and the following Observables
+	// flow resources allocated when connected
+	inflow      chan interface{} // the outflow of pred, or the relay of it when traced
+	traced_by   *Observable      // this or the nearest predecessor with a tracer, nil if untraced
+	trace_index int              // position in chain for tracing
+	trace_in_at atomic.Value     // time.Time when the latest item flowed in, for tracing
 }
 
 func newObservable() *Observable {
@@ -156,12 +191,76 @@ func newObservable() *Observable {
 }
 
 // connect all Observable form the first one.
-func (o *Observable) connect(ctx context.Context) {
+// The Observables before one run with a context that it can cancel by cancelUpstream, so that operators
+// such as Take stop the upstream while the downstream goes on. release frees these contexts
+func (o *Observable) connect(ctx context.Context) (release context.CancelFunc) {
+	var chain []*Observable
 	for po := o.root; po != nil; po = po.next {
+		chain = append(chain, po)
+	}
+	ctxs := make([]context.Context, len(chain))
+	cancels := make([]context.CancelFunc, 0, len(chain))
+	for i := len(chain) - 1; i > 0; i-- {
+		up, cancel := context.WithCancel(ctx)
+		ctxs[i] = context.WithValue(ctx, upstreamKey{}, cancel)
+		cancels = append(cancels, cancel)
+		ctx = up
+	}
+	ctxs[0] = ctx
+
+	var traced_by *Observable
+	for i, po := range chain {
+		if po.tracer != nil {
+			traced_by = po
+		}
+		// shared Observables are connected again while running, so only write changes
+		if po.traced_by != traced_by || po.trace_index != i {
+			po.traced_by, po.trace_index = traced_by, i
+		}
 		po.outflow = make(chan interface{}, po.buf_len)
-		po.operator.op(ctx, po)
+		if po.pred != nil {
+			po.inflow = po.traceInflow(ctxs[i], po.pred.outflow)
+		}
+		po.operator.op(ctxs[i], po)
 		//fmt.Println("conneted", po.name, po.outflow)
 	}
+	return func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+type upstreamKey struct{}
+
+// cancel the context of the Observables before the one running with ctx
+func cancelUpstream(ctx context.Context) {
+	if cancel, ok := ctx.Value(upstreamKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+}
+
+// stop the Observables before the one running with ctx, and drain its inflow in background until they complete,
+// so that the Observable can complete at once
+func stopUpstream(ctx context.Context, in chan interface{}) {
+	cancelUpstream(ctx)
+	go func() {
+		for range in {
+		}
+	}()
+}
+
+// connect the chain which o belongs to, and return the outflow of the last Observable.
+// operators use it to subscribe other Observables inside a flow
+func (o *Observable) connectFlow(ctx context.Context) chan interface{} {
+	ro := o
+	for ; ro.next != nil; ro = ro.next {
+	}
+	ro.mu.Lock()
+	ro.connect(ctx) // released when ctx is canceled
+	ch := ro.outflow
+	ro.mu.Unlock()
+	return ch
 }
 
 func (o *Observable) SubscribeOn(t ThreadModel) *Observable {
@@ -205,10 +304,8 @@ func (o *Observable) Subscribe(ob interface{}) {
 	}
 
 	//fmt.Println("begin conneted", o.name)
-	o.connect(ctx)
-	if ctxok {
-		oc.OnConnected()
-	}
+	release := o.connect(ctx)
+	defer release()
 
 	//get the last ob servable
 	po := o
@@ -217,6 +314,9 @@ func (o *Observable) Subscribe(ob interface{}) {
 
 	in := po.outflow
 	o.mu.Unlock()
+	if ctxok {
+		oc.OnConnected()
+	}
 
 	for x := range in {
 		if observer != nil {
@@ -264,26 +364,46 @@ func (o *Observable) Debug(debug bool) *Observable {
 
 func (o *Observable) sendToFlow(ctx context.Context, item interface{}, out chan interface{}) (end bool) {
 	//fmt.Println("send chan ", o.name, item, out)
+	if o.ordered && ctx.Value(poolTaskKey{}) != nil {
+		// the outflow of an ordered pool task, items are monitored and backpressured
+		// when the sequencer sends them out of the operator
+		select {
+		case out <- item:
+		case <-ctx.Done():
+			end = true
+		}
+		return
+	}
+	if o.backpressure != BackpressureBlock {
+		return o.sendWithBackpressure(ctx, item, out)
+	}
 	select {
 	case out <- item:
-		if e, ok := item.(error); ok {
-			if o.debug != nil {
-				o.debug.OnError(e)
-			}
-		} else {
-			if o.debug != nil {
-				o.debug.OnNext(item)
-			}
-		}
+		o.monitorItem(item, out)
 	case <-ctx.Done():
 		end = true
 	}
 	return
 }
 
+// report an item sent to flow to the monitor and the tracer
+func (o *Observable) monitorItem(item interface{}, out chan interface{}) {
+	o.traceOutflow(item, out, false)
+	if e, ok := item.(error); ok {
+		if o.debug != nil {
+			o.debug.OnError(e)
+		}
+	} else {
+		if o.debug != nil {
+			o.debug.OnNext(item)
+		}
+	}
+}
+
 func (o *Observable) closeFlow(out chan interface{}) *Observable {
 	// maybe need waiting for parent observable closed
 	//fmt.Println("close chan ", o.name, out)
+	o.traceOutflow(nil, out, true)
 	close(out)
 	if o.debug != nil {
 		o.debug.OnCompleted()
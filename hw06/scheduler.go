@@ -0,0 +1,227 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scheduler supplies time for time-based operators and sources, such as Interval, Timer, Debounce and Sample.
+// The wall clock is used by default, and a TestScheduler makes them run on virtual time
+type Scheduler interface {
+	Now() time.Time
+	NewTimer(d time.Duration) SchedulerTimer
+	NewTicker(d time.Duration) SchedulerTimer
+}
+
+// SchedulerTimer is a timer or ticker created by Scheduler
+type SchedulerTimer interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// the wall clock scheduler
+var DefaultScheduler Scheduler = wallScheduler{}
+
+type wallScheduler struct{}
+
+func (wallScheduler) Now() time.Time {
+	return time.Now()
+}
+
+func (wallScheduler) NewTimer(d time.Duration) SchedulerTimer {
+	return wallTimer{time.NewTimer(d)}
+}
+
+func (wallScheduler) NewTicker(d time.Duration) SchedulerTimer {
+	return wallTicker{time.NewTicker(d)}
+}
+
+type wallTimer struct {
+	t *time.Timer
+}
+
+func (w wallTimer) C() <-chan time.Time {
+	return w.t.C
+}
+
+func (w wallTimer) Stop() {
+	w.t.Stop()
+}
+
+type wallTicker struct {
+	t *time.Ticker
+}
+
+func (w wallTicker) C() <-chan time.Time {
+	return w.t.C
+}
+
+func (w wallTicker) Stop() {
+	w.t.Stop()
+}
+
+// SetScheduler set the scheduler of time-based operators. The Observables chained after it
+// use the same scheduler unless they set another one
+func (o *Observable) SetScheduler(s Scheduler) *Observable {
+	o.scheduler = s
+	return o
+}
+
+// get the scheduler of the Observable, which may be inherited from its predecessors
+func (o *Observable) getScheduler() Scheduler {
+	for po := o; po != nil; po = po.pred {
+		if po.scheduler != nil {
+			return po.scheduler
+		}
+	}
+	return DefaultScheduler
+}
+
+// TestScheduler is a Scheduler on virtual time, which only moves when Advance is called.
+// Timers fire one by one in order of time, and Advance waits for operators to settle down
+// after each firing, so a test runs in microseconds and gets the same result every time
+type TestScheduler struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*testTimer
+}
+
+type testTimer struct {
+	s      *TestScheduler
+	c      chan time.Time
+	when   time.Time
+	period time.Duration
+}
+
+// NewTestScheduler creates a TestScheduler starting at the Unix epoch
+func NewTestScheduler() *TestScheduler {
+	return &TestScheduler{now: time.Unix(0, 0)}
+}
+
+func (s *TestScheduler) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now
+}
+
+func (s *TestScheduler) NewTimer(d time.Duration) SchedulerTimer {
+	return s.newTimer(d, 0)
+}
+
+func (s *TestScheduler) NewTicker(d time.Duration) SchedulerTimer {
+	return s.newTimer(d, d)
+}
+
+func (s *TestScheduler) newTimer(d, period time.Duration) *testTimer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &testTimer{s, make(chan time.Time, 1), s.now.Add(d), period}
+	s.timers = append(s.timers, t)
+	return t
+}
+
+func (t *testTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *testTimer) Stop() {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+	t.s.remove(t)
+}
+
+func (s *TestScheduler) remove(t *testTimer) {
+	for i, ti := range s.timers {
+		if ti == t {
+			s.timers = append(s.timers[:i], s.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Advance moves the virtual time forward, and fires the timers on the way one by one
+func (s *TestScheduler) Advance(d time.Duration) {
+	s.Settle()
+	s.mu.Lock()
+	deadline := s.now.Add(d)
+	for {
+		var next *testTimer
+		for _, t := range s.timers {
+			if !t.when.After(deadline) && (next == nil || t.when.Before(next.when)) {
+				next = t
+			}
+		}
+		if next == nil {
+			break
+		}
+		s.now = next.when
+		if next.period > 0 {
+			next.when = next.when.Add(next.period)
+		} else {
+			s.remove(next)
+		}
+		// like time.Ticker, drop the tick if the last one is not received
+		select {
+		case next.c <- s.now:
+		default:
+		}
+		s.mu.Unlock()
+		s.Settle()
+		s.mu.Lock()
+	}
+	s.now = deadline
+	s.mu.Unlock()
+}
+
+// Pending returns the number of timers that have not fired yet
+func (s *TestScheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.timers)
+}
+
+// Settle waits until all the other goroutines are blocked, such as operators waiting for items, timers
+// or a test feeding a source. Nothing happens then until the virtual time moves or the test goes on,
+// so it does not depend on how fast the operators run
+func (s *TestScheduler) Settle() {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n == len(buf) {
+			buf = make([]byte, 2*len(buf))
+			continue
+		}
+		if !otherGoroutinesBusy(buf[:n]) {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+// otherGoroutinesBusy returns true if a goroutine in the stacks, except the calling one listed first,
+// is running or ready to run. Each goroutine starts with a header like "goroutine 7 [chan receive]:"
+func otherGoroutinesBusy(stacks []byte) bool {
+	for i, g := range bytes.Split(stacks, []byte("\n\n")) {
+		if i == 0 {
+			continue
+		}
+		start, end := bytes.IndexByte(g, '['), bytes.IndexByte(g, ']')
+		if start < 0 || end < start {
+			continue
+		}
+		// the state may be followed by details, such as "[running, locked to thread]"
+		state, _, _ := strings.Cut(string(g[start+1:end]), ",")
+		switch state {
+		case "running", "runnable", "preempted", "copystack":
+			return true
+		}
+	}
+	return false
+}
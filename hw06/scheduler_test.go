@@ -0,0 +1,50 @@
+package rxgo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestScheduler(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	start := s.Now()
+
+	timer := s.NewTimer(20 * time.Second)
+	ticker := s.NewTicker(15 * time.Second)
+	assert.Equal(t, 2, s.Pending(), "Pending Test Error!")
+
+	s.Advance(10 * time.Second)
+	assert.Equal(t, 10*time.Second, s.Now().Sub(start), "Advance Test Error!")
+	assert.Equal(t, 0, len(timer.C())+len(ticker.C()), "timers fired too early")
+
+	s.Advance(10 * time.Second)
+	assert.Equal(t, start.Add(15*time.Second), <-ticker.C(), "ticker fired at wrong time")
+	assert.Equal(t, start.Add(20*time.Second), <-timer.C(), "timer fired at wrong time")
+	assert.Equal(t, 1, s.Pending(), "a fired timer should be removed")
+
+	ticker.Stop()
+	s.Advance(time.Minute)
+	assert.Equal(t, 0, len(ticker.C()), "a stopped ticker should not fire")
+	assert.Equal(t, 0, s.Pending(), "Stop Test Error!")
+}
+
+func TestSchedulerInherited(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	res := []int{}
+	done := make(chan struct{})
+	go func() {
+		rxgo.Timer(time.Hour).SetScheduler(s).Map(func(x int) int {
+			return x + 1
+		}).Debounce(time.Hour).Subscribe(func(x int) {
+			res = append(res, x)
+		})
+		close(done)
+	}()
+
+	s.Advance(time.Hour)
+	<-done
+	assert.Equal(t, []int{1}, res, "Scheduler should be inherited by the chain")
+}
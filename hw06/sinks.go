@@ -0,0 +1,274 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+)
+
+// Sinks write the items emitted by an Observable, and then emit them. A failed item is emitted
+// in FlowableError with the write error instead, so that observers get it by OnError.
+// Errors are not written, they flow at once. Writes block the flow, so a slow sink holds back
+// the upstream as a slow observer does, or lets the backpressure strategy of the upstream work
+
+// EncodeCSV returns it if the item is not a record, which is []string or []interface{}
+var ErrRecordType = errors.New("Not a CSV record!")
+
+// ToChannel emits it in FlowableError if the item can not be sent to the channel
+var ErrChanType = errors.New("Channel type error!")
+
+// Encoder writes an item to w, such as EncodeLines, EncodeJSONLines and EncodeCSV
+type Encoder func(w io.Writer, x interface{}) error
+
+// EncodeLines writes an item in a line, string and []byte are written as they are, others are formatted by fmt
+func EncodeLines(w io.Writer, x interface{}) (err error) {
+	switch v := x.(type) {
+	case []byte:
+		if _, err = w.Write(v); err == nil {
+			_, err = io.WriteString(w, "\n")
+		}
+	default:
+		_, err = fmt.Fprintln(w, v)
+	}
+	return
+}
+
+// EncodeJSONLines writes an item in a line of JSON
+func EncodeJSONLines(w io.Writer, x interface{}) error {
+	return json.NewEncoder(w).Encode(x)
+}
+
+// EncodeCSV writes a record of `[]string` or `[]interface{}` in a line of CSV, fields of `[]interface{}` are formatted by fmt
+func EncodeCSV(w io.Writer, x interface{}) error {
+	var record []string
+	switch v := x.(type) {
+	case []string:
+		record = v
+	case []interface{}:
+		for _, f := range v {
+			record = append(record, fmt.Sprint(f))
+		}
+	default:
+		return ErrRecordType
+	}
+	cw := csv.NewWriter(w)
+	cw.Write(record)
+	cw.Flush()
+	return cw.Error()
+}
+
+// ToWriter writes each item emitted by an Observable to w by enc, or EncodeLines if enc is nil.
+// An item is written by one call of w.Write, and w is not closed
+func (parent *Observable) ToWriter(w io.Writer, enc Encoder) (o *Observable) {
+	o = parent.newSinkObservable("ToWriter", func() (sinkWriter, error) {
+		return &encodedWriter{w: w, enc: enc}, nil
+	})
+	return o
+}
+
+// ToFile writes each item emitted by an Observable to the file at path by enc, or EncodeLines if enc is nil.
+// Items are appended to the file. It is rotated before the size exceeds maxSize, unless maxSize <= 0,
+// to path.1 with at most backups older files path.2, path.3 ..., and the file of an item larger than maxSize
+// exceeds it. The error is emitted and the Observable stops if the file can not be opened
+func (parent *Observable) ToFile(path string, enc Encoder, maxSize int64, backups int) (o *Observable) {
+	o = parent.newSinkObservable("ToFile", func() (sinkWriter, error) {
+		fw := &fileWriter{encodedWriter: encodedWriter{enc: enc}, path: path, max_size: maxSize, backups: backups}
+		return fw, fw.open()
+	})
+	return o
+}
+
+// ToChannel sends each item emitted by an Observable to ch. The caller owns ch, it is never closed by ToChannel,
+// so that the Observable can be subscribed again, such as by Repeat. Close it after Subscribe returns if needed.
+// The item is emitted in FlowableError with ErrChanType if ch can not receive it
+func (parent *Observable) ToChannel(ch interface{}) (o *Observable) {
+	cv := reflect.ValueOf(ch)
+	if cv.Kind() != reflect.Chan || cv.Type().ChanDir()&reflect.SendDir == 0 {
+		panic(ErrFuncFlip)
+	}
+	o = parent.newSinkObservable("ToChannel", func() (sinkWriter, error) {
+		return chanWriter{cv}, nil
+	})
+	return o
+}
+
+// write an item of a sink, which is closed when the flow ends
+type sinkWriter interface {
+	write(ctx context.Context, x interface{}) error
+	close() error
+}
+
+// initialize a new Observable writing items to the sink opened when connected
+func (parent *Observable) newSinkObservable(name string, open func() (sinkWriter, error)) (o *Observable) {
+	o = parent.newTransformObservable(name)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			w, err := open()
+			if err != nil {
+				stopUpstream(ctx, in)
+				o.sendToFlow(ctx, err, out)
+				return
+			}
+			defer func() {
+				if err := w.close(); err != nil {
+					o.sendToFlow(ctx, err, out)
+				}
+			}()
+
+			for x := range in {
+				if !isError(x) {
+					if err := w.write(ctx, x); err != nil {
+						x = FlowableError{Err: err, Elements: x}
+					}
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+		},
+	}
+	return o
+}
+
+type encodedWriter struct {
+	w   io.Writer
+	enc Encoder
+	buf bytes.Buffer
+}
+
+// encode the item into buf
+func (ew *encodedWriter) encode(x interface{}) error {
+	ew.buf.Reset()
+	if ew.enc == nil {
+		return EncodeLines(&ew.buf, x)
+	}
+	return ew.enc(&ew.buf, x)
+}
+
+func (ew *encodedWriter) write(ctx context.Context, x interface{}) error {
+	if err := ew.encode(x); err != nil {
+		return err
+	}
+	_, err := ew.w.Write(ew.buf.Bytes())
+	return err
+}
+
+func (ew *encodedWriter) close() error {
+	return nil
+}
+
+type fileWriter struct {
+	encodedWriter
+	path     string
+	max_size int64
+	backups  int
+	file     *os.File
+	size     int64
+}
+
+func (fw *fileWriter) open() error {
+	f, err := os.OpenFile(fw.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	fw.file, fw.w, fw.size = f, f, fi.Size()
+	return nil
+}
+
+// shift the backups, move the file to path.1 and open a new one
+func (fw *fileWriter) rotate() error {
+	if err := fw.file.Close(); err != nil {
+		return err
+	}
+	var err error
+	if fw.backups <= 0 {
+		err = os.Remove(fw.path)
+	} else {
+		for i := fw.backups - 1; i > 0; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", fw.path, i), fmt.Sprintf("%s.%d", fw.path, i+1))
+		}
+		err = os.Rename(fw.path, fw.path+".1")
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return fw.open()
+}
+
+func (fw *fileWriter) write(ctx context.Context, x interface{}) error {
+	if fw.file == nil {
+		return os.ErrClosed
+	}
+	if err := fw.encode(x); err != nil {
+		return err
+	}
+	if fw.max_size > 0 && fw.size > 0 && fw.size+int64(fw.buf.Len()) > fw.max_size {
+		if err := fw.rotate(); err != nil {
+			fw.file = nil
+			return err
+		}
+	}
+	n, err := fw.file.Write(fw.buf.Bytes())
+	fw.size += int64(n)
+	return err
+}
+
+func (fw *fileWriter) close() error {
+	if fw.file == nil {
+		return nil
+	}
+	return fw.file.Close()
+}
+
+type chanWriter struct {
+	ch reflect.Value
+}
+
+func (cw chanWriter) write(ctx context.Context, x interface{}) error {
+	elem := cw.ch.Type().Elem()
+	var xv reflect.Value
+	switch {
+	case x != nil && reflect.TypeOf(x).AssignableTo(elem):
+		xv = reflect.ValueOf(x)
+	case x == nil && isNilable(elem.Kind()):
+		xv = reflect.Zero(elem)
+	default:
+		return ErrChanType
+	}
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: cw.ch, Send: xv},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	}
+	if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// the channel is owned by the caller
+func (cw chanWriter) close() error {
+	return nil
+}
+
+func isNilable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package rxgo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (f failingWriter) Write(p []byte) (int, error) {
+	return 0, f.err
+}
+
+func TestToWriter(t *testing.T) {
+	var sb strings.Builder
+	e := errors.New("oops")
+	res := collect(rxgo.Just("a", e, 1, []byte("b")).ToWriter(&sb, nil))
+	assert.Equal(t, []interface{}{"a", e, 1, []byte("b")}, res, "ToWriter Test Error!")
+	assert.Equal(t, "a\n1\nb\n", sb.String(), "ToWriter Lines Test Error!")
+
+	sb.Reset()
+	collect(rxgo.Just(map[string]int{"a": 1}, []int{1, 2}).ToWriter(&sb, rxgo.EncodeJSONLines))
+	assert.Equal(t, "{\"a\":1}\n[1,2]\n", sb.String(), "ToWriter JSONLines Test Error!")
+
+	sb.Reset()
+	res = collect(rxgo.Just([]string{"a", "b,c"}, 3, []interface{}{1, "x"}).ToWriter(&sb, rxgo.EncodeCSV))
+	assert.Equal(t, "a,\"b,c\"\n1,x\n", sb.String(), "ToWriter CSV Test Error!")
+	assert.Equal(t, rxgo.FlowableError{Err: rxgo.ErrRecordType, Elements: 3}, res[1], "ToWriter CSV Test Error!")
+}
+
+func TestToWriterError(t *testing.T) {
+	e := errors.New("disk full")
+	var errs []error
+	rxgo.Just(1, 2).ToWriter(failingWriter{e}, nil).Subscribe(rxgo.ObserverMonitor{
+		Error: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+	assert.Equal(t, []error{rxgo.FlowableError{Err: e, Elements: 1}, rxgo.FlowableError{Err: e, Elements: 2}}, errs, "ToWriter Error Test Error!")
+}
+
+func TestToChannel(t *testing.T) {
+	ch := make(chan int, 10)
+	res := collect(rxgo.Just(1, "x", 2).ToChannel(ch))
+	assert.Equal(t, []interface{}{1, rxgo.FlowableError{Err: rxgo.ErrChanType, Elements: "x"}, 2}, res, "ToChannel Test Error!")
+
+	// the channel is not closed, so that it can be subscribed again
+	res = collect(rxgo.Just(3).ToChannel(ch).Repeat(2))
+	assert.Equal(t, []interface{}{3, 3}, res, "ToChannel Repeat Test Error!")
+	close(ch)
+
+	got := []int{}
+	for x := range ch {
+		got = append(got, x)
+	}
+	assert.Equal(t, []int{1, 2, 3, 3}, got, "ToChannel Received Test Error!")
+}
+
+func TestToChannelCancel(t *testing.T) {
+	ch := make(chan interface{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rxgo.Just(1, 2).ToChannel(ch).Subscribe(rxgo.ObserverMonitor{
+		Context: func() context.Context {
+			return ctx
+		},
+	})
+	select {
+	case x := <-ch:
+		t.Errorf("ToChannel Cancel Test Error! %v is received", x)
+	default:
+	}
+}
+
+func TestToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	res := collect(rxgo.Range(0, 10).ToFile(path, nil, 6, 2))
+	assert.Equal(t, 10, len(res), "ToFile Test Error!")
+
+	for suffix, expected := range map[string]string{
+		"":   "9\n",
+		".1": "6\n7\n8\n",
+		".2": "3\n4\n5\n",
+	} {
+		b, err := os.ReadFile(path + suffix)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(b), "ToFile Rotation Test Error!")
+	}
+	_, err := os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "ToFile Backups Test Error!")
+
+	// appended to the existing file
+	collect(rxgo.Just(10).ToFile(path, nil, 0, 0))
+	b, _ := os.ReadFile(path)
+	assert.Equal(t, "9\n10\n", string(b), "ToFile Append Test Error!")
+}
+
+func TestToFileOpenError(t *testing.T) {
+	res := collect(rxgo.Just(1).ToFile(filepath.Join(t.TempDir(), "missing", "out.log"), nil, 0, 0))
+	assert.Equal(t, 1, len(res), "ToFile Open Error Test Error!")
+	assert.True(t, errors.Is(res[0].(error), os.ErrNotExist), "ToFile Open Error Test Error!")
+}
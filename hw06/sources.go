@@ -0,0 +1,251 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// FromHTTPStream emits it in FlowableError with the status if the response is not 2xx
+var ErrHTTPStatus = errors.New("HTTP status error!")
+
+// interval of polling appended data by FromFileTail
+var TailInterval = 100 * time.Millisecond
+
+var readerSource = rangeSource
+var fileTailSource = rangeSource
+var signalSource = rangeSource
+
+// ServerSentEvent is an event of text/event-stream emitted by FromHTTPStream
+type ServerSentEvent struct {
+	ID    string
+	Event string // "message" if the event has no type
+	Data  string
+}
+
+// FromReader creates an Observable that emits the tokens of r split by split in string, such as
+// bufio.ScanLines (the default one if split is nil), bufio.ScanWords or bufio.ScanRunes.
+// An error in reading is emitted before completion. r is not closed, and a blocked Read is left behind
+// when the subscriber unsubscribes, which ends at its next return
+func FromReader(r io.Reader, split bufio.SplitFunc) *Observable {
+	return newReaderObservable("FromReader", r, nil, split, newTokenDecoder)
+}
+
+// FromJSONLines creates an Observable that emits the values decoded from each non-empty line of r,
+// a line which is not JSON is emitted in FlowableError with the line. r is not closed as FromReader
+func FromJSONLines(r io.Reader) *Observable {
+	return newReaderObservable("FromJSONLines", r, nil, bufio.ScanLines, func() tokenDecoder {
+		return decodeJSONLine
+	})
+}
+
+// FromHTTPStream creates an Observable that emits the body of a streaming response, such as chunked one.
+// Events of text/event-stream are emitted in ServerSentEvent, and lines of other content types are emitted in string.
+// The body is closed when the Observable completes or the subscriber unsubscribes
+func FromHTTPStream(resp *http.Response) *Observable {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		o := Throw(FlowableError{Err: ErrHTTPStatus, Elements: resp.Status})
+		o.Name = "FromHTTPStream"
+		return o
+	}
+	newDecoder := newTokenDecoder
+	if t, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); t == "text/event-stream" {
+		newDecoder = newEventDecoder
+	}
+	return newReaderObservable("FromHTTPStream", resp.Body, resp.Body, bufio.ScanLines, newDecoder)
+}
+
+// FromFileTail creates an Observable that emits the lines of the file at path in string, and then follows
+// the data appended to it like `tail -f`. A line is emitted when its line feed is written. It reads again
+// from the beginning if the file is truncated. It never terminates until the subscriber unsubscribes,
+// or emits the error and completes if the file can not be read
+func FromFileTail(path string) *Observable {
+	o := newGeneratorObservable("FromFileTail")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		f, err := os.Open(path)
+		if err != nil {
+			o.sendToFlow(ctx, err, out)
+			return
+		}
+		defer f.Close()
+
+		r := bufio.NewReader(f)
+		var line string  // a line without its line feed yet
+		var offset int64 // offset of the data read
+		for {
+			s, err := r.ReadString('\n')
+			offset += int64(len(s))
+			line += s
+			if err == nil {
+				if o.sendToFlow(ctx, strings.TrimRight(line, "\r\n"), out) {
+					return
+				}
+				line = ""
+				continue
+			}
+			if err != io.EOF {
+				o.sendToFlow(ctx, err, out)
+				return
+			}
+
+			timer := o.getScheduler().NewTimer(TailInterval)
+			select {
+			case <-timer.C():
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+			if fi, err := f.Stat(); err == nil && fi.Size() < offset {
+				if _, err := f.Seek(0, io.SeekStart); err != nil {
+					o.sendToFlow(ctx, err, out)
+					return
+				}
+				r.Reset(f)
+				line, offset = "", 0
+			}
+		}
+	}
+	o.operator = fileTailSource
+	return o
+}
+
+// FromSignal creates an Observable that emits the incoming os.Signal of sigs, or all incoming signals if sigs is empty.
+// It never terminates until the subscriber unsubscribes, and the signals are not relayed to it after that
+func FromSignal(sigs ...os.Signal) *Observable {
+	o := newGeneratorObservable("FromSignal")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, sigs...)
+		defer signal.Stop(ch)
+		for {
+			select {
+			case sig := <-ch:
+				if o.sendToFlow(ctx, sig, out) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	o.operator = signalSource
+	return o
+}
+
+// decode a token into an item, nothing is emitted if ok is false
+type tokenDecoder func(token string) (item interface{}, ok bool)
+
+// create an Observable that emits the items decoded from the tokens of r. Tokens are read in a goroutine,
+// so that the Observable completes at once when the subscriber unsubscribes. closer is closed
+// at the end if it is not nil, which also stops a blocked Read
+func newReaderObservable(name string, r io.Reader, closer io.Closer, split bufio.SplitFunc, newDecoder func() tokenDecoder) *Observable {
+	o := newGeneratorObservable(name)
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		if closer != nil {
+			defer closer.Close()
+		}
+		items := make(chan interface{})
+		go func() {
+			defer close(items)
+			scanner := bufio.NewScanner(r)
+			if split != nil {
+				scanner.Split(split)
+			}
+			decode := newDecoder()
+			for scanner.Scan() {
+				item, ok := decode(scanner.Text())
+				if !ok {
+					continue
+				}
+				select {
+				case items <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil && ctx.Err() == nil {
+				select {
+				case items <- err:
+				case <-ctx.Done():
+				}
+			}
+		}()
+
+		for {
+			select {
+			case item, ok := <-items:
+				if !ok || o.sendToFlow(ctx, item, out) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	o.operator = readerSource
+	return o
+}
+
+func newTokenDecoder() tokenDecoder {
+	return func(token string) (interface{}, bool) {
+		return token, true
+	}
+}
+
+func decodeJSONLine(line string) (interface{}, bool) {
+	if strings.TrimSpace(line) == "" {
+		return nil, false
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(line), &v); err != nil {
+		return FlowableError{Err: err, Elements: line}, true
+	}
+	return v, true
+}
+
+// decoder of text/event-stream, an event is dispatched at a blank line
+func newEventDecoder() tokenDecoder {
+	var ev ServerSentEvent
+	var data []string
+	return func(line string) (interface{}, bool) {
+		if line == "" {
+			e, ok := ev, len(data) > 0
+			e.Data = strings.Join(data, "\n")
+			if e.Event == "" {
+				e.Event = "message"
+			}
+			ev.Event, data = "", nil // the last event ID is kept
+			return e, ok
+		}
+		if strings.HasPrefix(line, ":") {
+			return nil, false
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			ev.Event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			ev.ID = value
+		}
+		return nil, false
+	}
+}
@@ -0,0 +1,147 @@
+package rxgo_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		err = f.err
+	}
+	return n, err
+}
+
+func TestFromReader(t *testing.T) {
+	res := collect(rxgo.FromReader(strings.NewReader("a\r\nb\nc"), nil))
+	assert.Equal(t, []interface{}{"a", "b", "c"}, res, "FromReader Test Error!")
+
+	res = collect(rxgo.FromReader(strings.NewReader("to be  or\nnot"), bufio.ScanWords))
+	assert.Equal(t, []interface{}{"to", "be", "or", "not"}, res, "FromReader Words Test Error!")
+
+	e := errors.New("broken")
+	res = collect(rxgo.FromReader(failingReader{strings.NewReader("a\nb\n"), e}, nil))
+	assert.Equal(t, []interface{}{"a", "b", e}, res, "FromReader Error Test Error!")
+}
+
+func TestFromReaderCancel(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rxgo.FromReader(r, nil).Subscribe(rxgo.ObserverMonitor{
+		Context: func() context.Context {
+			return ctx
+		},
+	})
+}
+
+func TestFromJSONLines(t *testing.T) {
+	res := collect(rxgo.FromJSONLines(strings.NewReader("{\"a\":1}\n\nbad\n[1]\n")))
+	assert.Equal(t, 3, len(res), "FromJSONLines Test Error!")
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, res[0], "FromJSONLines Test Error!")
+	assert.Equal(t, "bad", res[1].(rxgo.FlowableError).Elements, "FromJSONLines Error Test Error!")
+	assert.Equal(t, []interface{}{1.0}, res[2], "FromJSONLines Test Error!")
+}
+
+func TestFromHTTPStream(t *testing.T) {
+	closed := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(closed)
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		fmt.Fprint(w, ": hello\n\nid: 1\ndata: a\ndata: b\n\nevent: tick\ndata: c\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done() // hold the stream until the client closes it
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	res := collect(rxgo.FromHTTPStream(resp).Take(2))
+	assert.Equal(t, []interface{}{
+		rxgo.ServerSentEvent{ID: "1", Event: "message", Data: "a\nb"},
+		rxgo.ServerSentEvent{ID: "1", Event: "tick", Data: "c"},
+	}, res, "FromHTTPStream Test Error!")
+	<-closed
+}
+
+func TestFromHTTPStreamStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	res := collect(rxgo.FromHTTPStream(resp))
+	assert.Equal(t, []interface{}{rxgo.FlowableError{Err: rxgo.ErrHTTPStatus, Elements: "404 Not Found"}}, res, "FromHTTPStream Status Test Error!")
+}
+
+func TestFromFileTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tail.log")
+	assert.NoError(t, os.WriteFile(path, []byte("a\nb"), 0644))
+
+	s := rxgo.NewTestScheduler()
+	done := collectAsync(rxgo.FromFileTail(path).SetScheduler(s).Take(3))
+	waitTimer := func() {
+		for s.Pending() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitTimer()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	f.WriteString("\n")
+	f.Close()
+	s.Advance(rxgo.TailInterval)
+
+	waitTimer()
+	assert.NoError(t, os.WriteFile(path, []byte("x\n"), 0644))
+	s.Advance(rxgo.TailInterval)
+	assert.Equal(t, []interface{}{"a", "b", "x"}, <-done, "FromFileTail Test Error!")
+
+	res := collect(rxgo.FromFileTail(filepath.Join(t.TempDir(), "missing")))
+	assert.Equal(t, 1, len(res), "FromFileTail Missing Test Error!")
+	assert.True(t, errors.Is(res[0].(error), os.ErrNotExist), "FromFileTail Missing Test Error!")
+}
+
+func TestFromSignal(t *testing.T) {
+	// keep the process alive whenever the signal arrives
+	guard := make(chan os.Signal, 1)
+	signal.Notify(guard, os.Interrupt)
+	defer signal.Stop(guard)
+
+	p, err := os.FindProcess(os.Getpid())
+	assert.NoError(t, err)
+	done := collectAsync(rxgo.FromSignal(os.Interrupt).Take(1))
+	for {
+		if err := p.Signal(os.Interrupt); err != nil {
+			t.Skip("can not send signals:", err)
+		}
+		select {
+		case res := <-done:
+			assert.Equal(t, []interface{}{os.Interrupt}, res, "FromSignal Test Error!")
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"sync"
+)
+
+type subjectKind uint
+
+const (
+	publishSubject subjectKind = iota
+	behaviorSubject
+	replaySubject
+	asyncSubject
+)
+
+// A Subject acts both as an Observer and as an Observable source. Items pushed by its OnNext and OnError
+// are multicast to all of its subscribers, it completes them when OnCompleted is called.
+// It is safe to push items from several goroutines
+type Subject struct {
+	*Observable
+	kind subjectKind
+	hub  *multicast
+	mu   sync.Mutex
+	last interface{} // the last item of AsyncSubject
+	has  bool
+}
+
+var _ Observer = &Subject{}
+
+// NewPublishSubject creates a Subject that emits to a subscriber only the items pushed after it subscribed
+func NewPublishSubject() *Subject {
+	return newSubject("PublishSubject", publishSubject, 0)
+}
+
+// NewBehaviorSubject creates a Subject that emits to a subscriber the most recent item (or the initial item
+// if none has been pushed yet), and then continues to emit the items pushed later
+func NewBehaviorSubject(initial interface{}) *Subject {
+	s := newSubject("BehaviorSubject", behaviorSubject, 1)
+	s.hub.next(initial)
+	return s
+}
+
+// NewReplaySubject creates a Subject that emits to a subscriber the latest n items pushed before it subscribed,
+// or all of them if n is negative, regardless of when the subscriber subscribes
+func NewReplaySubject(n int) *Subject {
+	return newSubject("ReplaySubject", replaySubject, n)
+}
+
+// NewAsyncSubject creates a Subject that emits only the last item pushed, and only after OnCompleted is called.
+// An error ends it at once, the error is emitted instead of the last item, and nothing pushed later is emitted
+func NewAsyncSubject() *Subject {
+	return newSubject("AsyncSubject", asyncSubject, 1)
+}
+
+// newGroupSubject creates a Subject that keeps the items pushed until it is first subscribed, and replays them
+// only to the first subscriber. The later subscribers get only the items pushed after they subscribed
+func newGroupSubject() *Subject {
+	s := newSubject("GroupSubject", replaySubject, -1)
+	s.hub.once = true
+	return s
+}
+
+func newSubject(name string, kind subjectKind, replay int) *Subject {
+	s := &Subject{kind: kind, hub: newMulticast(replay)}
+	s.Observable = newGeneratorObservable(name)
+	s.operator = multicastOperator{func() *multicast { return s.hub }, nil}
+	return s
+}
+
+// OnNext pushes an item to the subscribers
+func (s *Subject) OnNext(x interface{}) {
+	if s.kind == asyncSubject {
+		s.mu.Lock()
+		s.last, s.has = x, true
+		s.mu.Unlock()
+		return
+	}
+	s.hub.next(x)
+}
+
+// OnError pushes an error to the subscribers. Like other Observables, an error does not terminate a Subject,
+// except AsyncSubject
+func (s *Subject) OnError(e error) {
+	if s.kind == asyncSubject {
+		s.mu.Lock()
+		s.last, s.has = nil, false
+		s.hub.next(e)
+		s.hub.complete(false)
+		s.mu.Unlock()
+		return
+	}
+	s.OnNext(e)
+}
+
+// OnCompleted completes all the subscribers, the items pushed later are ignored
+func (s *Subject) OnCompleted() {
+	if s.kind == asyncSubject {
+		s.mu.Lock()
+		if s.has {
+			s.hub.next(s.last)
+		}
+		s.mu.Unlock()
+	}
+	s.hub.complete(s.kind == behaviorSubject)
+}
@@ -0,0 +1,161 @@
+package rxgo_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishSubject(t *testing.T) {
+	s := rxgo.NewPublishSubject()
+	s.OnNext(0) // no subscriber
+
+	var wg sync.WaitGroup
+	res1, res2 := []int{}, []int{}
+	subscribeAsync(s.Observable, &res1, &wg)
+	s.OnNext(1)
+	subscribeAsync(s.Map(func(x int) int {
+		return x * 10
+	}), &res2, &wg)
+	s.OnNext(2)
+	s.OnCompleted()
+	s.OnNext(3)
+	wg.Wait()
+
+	assert.Equal(t, []int{1, 2}, res1, "PublishSubject Test Error!")
+	assert.Equal(t, []int{20}, res2, "PublishSubject Test Error!")
+}
+
+func TestBehaviorSubject(t *testing.T) {
+	s := rxgo.NewBehaviorSubject(0)
+
+	var wg sync.WaitGroup
+	res1, res2 := []int{}, []int{}
+	subscribeAsync(s.Observable, &res1, &wg)
+	s.OnNext(1)
+	s.OnNext(2)
+	subscribeAsync(s.Observable, &res2, &wg)
+	s.OnNext(3)
+	s.OnCompleted()
+	wg.Wait()
+
+	assert.Equal(t, []int{0, 1, 2, 3}, res1, "BehaviorSubject Test Error!")
+	assert.Equal(t, []int{2, 3}, res2, "BehaviorSubject Test Error!")
+
+	res3 := []int{}
+	s.Subscribe(func(x int) {
+		res3 = append(res3, x)
+	})
+	assert.Equal(t, []int{}, res3, "BehaviorSubject should emit nothing after completed")
+}
+
+func TestReplaySubject(t *testing.T) {
+	s := rxgo.NewReplaySubject(2)
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnNext(3)
+
+	var wg sync.WaitGroup
+	res1 := []int{}
+	subscribeAsync(s.Observable, &res1, &wg)
+	s.OnNext(4)
+	s.OnCompleted()
+	wg.Wait()
+
+	res2 := []int{}
+	s.Subscribe(func(x int) {
+		res2 = append(res2, x)
+	})
+	assert.Equal(t, []int{2, 3, 4}, res1, "ReplaySubject Test Error!")
+	assert.Equal(t, []int{3, 4}, res2, "ReplaySubject Test Error!")
+}
+
+func TestAsyncSubject(t *testing.T) {
+	s := rxgo.NewAsyncSubject()
+
+	var wg sync.WaitGroup
+	res1 := []int{}
+	subscribeAsync(s.Observable, &res1, &wg)
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnCompleted()
+	wg.Wait()
+
+	res2 := []int{}
+	s.Subscribe(func(x int) {
+		res2 = append(res2, x)
+	})
+	assert.Equal(t, []int{2}, res1, "AsyncSubject Test Error!")
+	assert.Equal(t, []int{2}, res2, "AsyncSubject Test Error!")
+}
+
+func TestAsyncSubjectError(t *testing.T) {
+	ee := errors.New("any")
+	s := rxgo.NewAsyncSubject()
+	collect := func() []interface{} {
+		res := []interface{}{}
+		s.Subscribe(rxgo.ObserverMonitor{
+			Next: func(x interface{}) {
+				res = append(res, x)
+			},
+			Error: func(e error) {
+				res = append(res, e)
+			},
+		})
+		return res
+	}
+
+	done := make(chan []interface{})
+	go func() {
+		done <- collect()
+	}()
+	s.OnNext(1)
+	s.OnError(ee)
+	s.OnNext(2)
+	s.OnCompleted()
+	assert.Equal(t, []interface{}{ee}, <-done, "AsyncSubject Error Test Error!")
+	assert.Equal(t, []interface{}{ee}, collect(), "AsyncSubject late Error Test Error!")
+}
+
+func TestSubjectAsObserver(t *testing.T) {
+	ee := errors.New("any")
+	s := rxgo.NewReplaySubject(-1)
+	rxgo.Just(1, ee, 2).Subscribe(s)
+
+	res := []interface{}{}
+	s.Subscribe(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			res = append(res, x)
+		},
+		Error: func(e error) {
+			res = append(res, e)
+		},
+	})
+	assert.Equal(t, []interface{}{1, ee, 2}, res, "Subject as Observer Test Error!")
+}
+
+func TestSubjectConcurrent(t *testing.T) {
+	s := rxgo.NewPublishSubject()
+
+	var wg sync.WaitGroup
+	res := []int{}
+	subscribeAsync(s.Observable, &res, &wg)
+
+	var pushers sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		pushers.Add(1)
+		go func() {
+			defer pushers.Done()
+			for j := 0; j < 100; j++ {
+				s.OnNext(j)
+			}
+		}()
+	}
+	pushers.Wait()
+	s.OnCompleted()
+	wg.Wait()
+	assert.Equal(t, 1000, len(res), "Subject lost items")
+}
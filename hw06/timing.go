@@ -0,0 +1,178 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Timeout emits it if the Observable emits no item in time
+var ErrTimeout = errors.New("Timeout!")
+
+var delaySubscriptionSource = rangeSource
+
+// Timed is an item emitted with its arrival time, and the time elapsed since the previous item
+// or the subscription for the first one. Interval is always zero for Timestamp
+type Timed struct {
+	Item     interface{}
+	Time     time.Time
+	Interval time.Duration
+}
+
+// Timeout emits ErrTimeout and stops the Observable if it emits no item or error in the timespan
+// after the subscription or its previous item
+func (parent *Observable) Timeout(timespan time.Duration) (o *Observable) {
+	o = parent.TimeoutWithFallback(timespan, Throw(ErrTimeout))
+	o.Name = "Timeout"
+	return o
+}
+
+// TimeoutWithFallback stops the Observable and switches to the fallback if it emits no item or error in the timespan
+// after the subscription or its previous item. It completes at the timeout if the fallback is nil
+func (parent *Observable) TimeoutWithFallback(timespan time.Duration, fallback *Observable) (o *Observable) {
+	o = parent.newTransformObservable("TimeoutWithFallback")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			clk := o.getScheduler()
+			timer := clk.NewTimer(timespan)
+			defer func() {
+				timer.Stop()
+			}()
+
+			for {
+				select {
+				case x, ok := <-in:
+					if !ok {
+						return
+					}
+					timer.Stop()
+					if o.sendToFlow(ctx, x, out) {
+						return
+					}
+					timer = clk.NewTimer(timespan)
+				case <-timer.C():
+					stopUpstream(ctx, in)
+					if fallback != nil {
+						o.forwardFlow(ctx, fallback, out)
+					}
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		},
+	}
+	return o
+}
+
+// Delay shifts the items emitted by an Observable forward in time by the timespan, keeping their intervals.
+// Errors are delayed as items, and the Observable completes after its last item is emitted
+func (parent *Observable) Delay(timespan time.Duration) (o *Observable) {
+	o = parent.newTransformObservable("Delay")
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			clk := o.getScheduler()
+			var queue []Timed // items with their due time
+			var timer SchedulerTimer
+			var fire <-chan time.Time
+			defer func() {
+				if timer != nil {
+					timer.Stop()
+				}
+			}()
+
+			for {
+				now := clk.Now()
+				for len(queue) > 0 && !queue[0].Time.After(now) {
+					if o.sendToFlow(ctx, queue[0].Item, out) {
+						return
+					}
+					queue = queue[1:]
+				}
+				if in == nil && len(queue) == 0 {
+					return
+				}
+				if fire == nil && len(queue) > 0 {
+					timer = clk.NewTimer(queue[0].Time.Sub(now))
+					fire = timer.C()
+				}
+
+				select {
+				case x, ok := <-in:
+					if !ok {
+						in = nil
+						continue
+					}
+					queue = append(queue, Timed{Item: x, Time: clk.Now().Add(timespan)})
+				case <-fire:
+					timer, fire = nil, nil
+				case <-ctx.Done():
+					return
+				}
+			}
+		},
+	}
+	return o
+}
+
+// DelaySubscription subscribes the Observable after the timespan. The delay runs on the scheduler
+// of the source Observable, or the one set to the returned Observable
+func (parent *Observable) DelaySubscription(timespan time.Duration) *Observable {
+	o := newGeneratorObservable("DelaySubscription")
+
+	o.flip = func(ctx context.Context, out chan interface{}) {
+		s := o.scheduler
+		if s == nil {
+			s = parent.getScheduler()
+		}
+		timer := s.NewTimer(timespan)
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+			o.forwardFlow(ctx, parent, out)
+		case <-ctx.Done():
+		}
+	}
+	o.operator = delaySubscriptionSource
+	return o
+}
+
+// Timestamp emits each item emitted by an Observable in Timed, with its arrival time only. Errors flow at once
+func (parent *Observable) Timestamp() (o *Observable) {
+	return parent.newTimedObservable("Timestamp", false)
+}
+
+// TimeInterval emits each item emitted by an Observable in Timed, with its arrival time and the time elapsed
+// since the previous item, or the subscription for the first one. Errors flow at once
+func (parent *Observable) TimeInterval() (o *Observable) {
+	return parent.newTimedObservable("TimeInterval", true)
+}
+
+// newTimedObservable wraps items in Timed, the Interval is set only if interval is true
+func (parent *Observable) newTimedObservable(name string, interval bool) (o *Observable) {
+	o = parent.newTransformObservable(name)
+	o.operator = flowOperator{
+		opFunc: func(ctx context.Context, o *Observable, in chan interface{}, out chan interface{}) {
+			clk := o.getScheduler()
+			last := clk.Now()
+			for x := range in {
+				if !isError(x) {
+					now := clk.Now()
+					t := Timed{Item: x, Time: now}
+					if interval {
+						t.Interval = now.Sub(last)
+					}
+					x, last = t, now
+				}
+				if o.sendToFlow(ctx, x, out) {
+					return
+				}
+			}
+		},
+	}
+	return o
+}
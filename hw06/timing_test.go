@@ -0,0 +1,130 @@
+package rxgo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// collect the Observable in background, the result is received from the returned channel
+func collectAsync(o *rxgo.Observable) chan []interface{} {
+	done := make(chan []interface{})
+	go func() {
+		done <- collect(o)
+	}()
+	return done
+}
+
+// times of Timed items in milliseconds since the epoch of TestScheduler
+func timedMillis(res []interface{}) (items []interface{}, times []int64) {
+	for _, x := range res {
+		if t, ok := x.(rxgo.Timed); ok {
+			items = append(items, t.Item)
+			times = append(times, t.Time.Sub(time.Unix(0, 0)).Milliseconds())
+		}
+	}
+	return
+}
+
+func TestTimeout(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	done := collectAsync(rxgo.From(ch).Timeout(25 * time.Millisecond).SetScheduler(s))
+	ch <- 1
+	s.Advance(10 * time.Millisecond)
+	ch <- 2
+	s.Advance(30 * time.Millisecond)
+	assert.Equal(t, []interface{}{1, 2, rxgo.ErrTimeout}, <-done, "Timeout Test Error!")
+}
+
+func TestTimeoutWithFallback(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	done := collectAsync(rxgo.Never().TimeoutWithFallback(10*time.Millisecond, rxgo.Just(1, 2)).SetScheduler(s))
+	s.Advance(10 * time.Millisecond)
+	assert.Equal(t, []interface{}{1, 2}, <-done, "TimeoutWithFallback Test Error!")
+
+	res := collect(rxgo.Just(1, 2).TimeoutWithFallback(time.Hour, rxgo.Just(3)))
+	assert.Equal(t, []interface{}{1, 2}, res, "TimeoutWithFallback NoTimeout Test Error!")
+}
+
+func TestDelay(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	done := collectAsync(rxgo.From(ch).Delay(20 * time.Millisecond).SetScheduler(s).Timestamp())
+	ch <- 1
+	s.Advance(10 * time.Millisecond)
+	ch <- 2
+	close(ch)
+	s.Advance(15 * time.Millisecond)
+	s.Advance(10 * time.Millisecond)
+	items, times := timedMillis(<-done)
+	assert.Equal(t, []interface{}{1, 2}, items, "Delay Test Error!")
+	assert.Equal(t, []int64{20, 30}, times, "Delay Time Test Error!")
+}
+
+func TestDelaySubscription(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	done := collectAsync(rxgo.Just(1, 2).DelaySubscription(20 * time.Millisecond).SetScheduler(s).Timestamp())
+	s.Advance(10 * time.Millisecond)
+	s.Advance(10 * time.Millisecond)
+	items, times := timedMillis(<-done)
+	assert.Equal(t, []interface{}{1, 2}, items, "DelaySubscription Test Error!")
+	assert.Equal(t, []int64{20, 20}, times, "DelaySubscription Time Test Error!")
+}
+
+func TestTimeInterval(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	done := collectAsync(rxgo.From(ch).TimeInterval().SetScheduler(s))
+	s.Advance(5 * time.Millisecond)
+	ch <- 1
+	s.Advance(10 * time.Millisecond)
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	intervals := []time.Duration{}
+	for _, x := range <-done {
+		intervals = append(intervals, x.(rxgo.Timed).Interval)
+	}
+	assert.Equal(t, []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 0}, intervals, "TimeInterval Test Error!")
+}
+
+func TestTimestamp(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	ch := make(chan int)
+	done := collectAsync(rxgo.From(ch).Timestamp().SetScheduler(s))
+	s.Advance(5 * time.Millisecond)
+	ch <- 1
+	s.Advance(10 * time.Millisecond)
+	ch <- 2
+	close(ch)
+
+	res := <-done
+	assert.Equal(t, 2, len(res), "Timestamp Test Error!")
+	for i, ms := range []int64{5, 15} {
+		x := res[i].(rxgo.Timed)
+		assert.Equal(t, i+1, x.Item, "Timestamp Test Error!")
+		assert.Equal(t, ms, x.Time.Sub(time.Unix(0, 0)).Milliseconds(), "Timestamp Time Test Error!")
+		assert.Equal(t, time.Duration(0), x.Interval, "Timestamp should not set Interval")
+	}
+}
+
+func TestTimingContext(t *testing.T) {
+	for _, o := range []*rxgo.Observable{
+		rxgo.Interval(time.Millisecond).Delay(time.Hour),
+		rxgo.Just(1).DelaySubscription(time.Hour),
+		rxgo.Never().Timeout(time.Hour),
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		o.Subscribe(rxgo.ObserverMonitor{
+			Context: func() context.Context {
+				return ctx
+			},
+		})
+		cancel()
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"time"
+)
+
+// TraceKind is the kind of a TraceEvent
+type TraceKind uint
+
+const (
+	TraceIn       TraceKind = iota // an item flows into the Observable
+	TraceOut                       // an item flows out of the Observable
+	TraceError                     // an error flows out of the Observable
+	TraceComplete                  // the outflow of the Observable is closed
+)
+
+func (k TraceKind) String() string {
+	switch k {
+	case TraceIn:
+		return "in"
+	case TraceOut:
+		return "out"
+	case TraceError:
+		return "error"
+	case TraceComplete:
+		return "complete"
+	}
+	return "unknown"
+}
+
+// TraceEvent is what happens in an Observable of a chain
+type TraceEvent struct {
+	Name      string        // name of the Observable
+	Index     int           // position of the Observable in the chain, the first one is 0
+	Kind      TraceKind     //
+	Item      interface{}   // the item or error, nil for TraceComplete
+	Time      time.Time     // time of the scheduler when it happens
+	Latency   time.Duration // for TraceOut and TraceError, the time since the latest item flowed in. zero for the first Observable
+	BufferLen int           // items in the outflow after TraceOut or TraceError
+	BufferCap int           // capacity of the outflow
+}
+
+// Tracer receives trace events of Observables, it is called in the goroutines of operators, so it must be safe for concurrent use
+type Tracer interface {
+	Trace(ev TraceEvent)
+}
+
+// TracerFunc is a function used as a Tracer
+type TracerFunc func(ev TraceEvent)
+
+func (f TracerFunc) Trace(ev TraceEvent) {
+	f(ev)
+}
+
+// SetTracer traces the Observable and those chained after it
+func (o *Observable) SetTracer(t Tracer) *Observable {
+	o.tracer = t
+	return o
+}
+
+// traceInflow returns a channel relaying the inflow of a traced Observable, which reports items flowing in
+func (o *Observable) traceInflow(ctx context.Context, in chan interface{}) chan interface{} {
+	if o.traced_by == nil {
+		return in
+	}
+	t, index := o.traced_by.tracer, o.trace_index
+	relay := make(chan interface{})
+	go func() {
+		defer close(relay)
+		for x := range in {
+			now := o.getScheduler().Now()
+			o.trace_in_at.Store(now)
+			t.Trace(TraceEvent{Name: o.Name, Index: index, Kind: TraceIn, Item: x, Time: now, BufferLen: len(in), BufferCap: cap(in)})
+			select {
+			case relay <- x:
+			case <-ctx.Done():
+				// keep draining, so that the predecessor is never blocked
+				for range in {
+				}
+				return
+			}
+		}
+	}()
+	return relay
+}
+
+// report an item flowed out or the completion of the Observable
+func (o *Observable) traceOutflow(item interface{}, out chan interface{}, complete bool) {
+	if o.traced_by == nil {
+		return
+	}
+	t, index := o.traced_by.tracer, o.trace_index
+	now := o.getScheduler().Now()
+	ev := TraceEvent{Name: o.Name, Index: index, Kind: TraceOut, Item: item, Time: now, BufferLen: len(out), BufferCap: cap(out)}
+	switch {
+	case complete:
+		ev.Kind, ev.BufferLen = TraceComplete, 0
+	case isError(item):
+		ev.Kind = TraceError
+	}
+	if in, ok := o.trace_in_at.Load().(time.Time); ok && !complete {
+		ev.Latency = now.Sub(in)
+	}
+	t.Trace(ev)
+}
+
+func isError(x interface{}) bool {
+	_, ok := x.(error)
+	return ok
+}
@@ -0,0 +1,148 @@
+package rxgo_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/stretchr/testify/assert"
+)
+
+type traceRecorder struct {
+	mu     sync.Mutex
+	events []rxgo.TraceEvent
+}
+
+func (r *traceRecorder) Trace(ev rxgo.TraceEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+// count events of the Observable at index by kind
+func (r *traceRecorder) count(index int, kind rxgo.TraceKind) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, ev := range r.events {
+		if ev.Index == index && ev.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func TestTracer(t *testing.T) {
+	r := &traceRecorder{}
+	e := errors.New("any")
+	res := collect(rxgo.Just(1, 2, e, 3).SetTracer(r).Map(func(x int) int {
+		return x * 2
+	}).Filter(func(x int) bool {
+		return x > 2
+	}))
+	assert.Equal(t, []interface{}{4, e, 6}, res, "Tracer should not change the flow")
+
+	assert.Equal(t, 0, r.count(0, rxgo.TraceIn), "Tracer Test Error!")
+	assert.Equal(t, 3, r.count(0, rxgo.TraceOut), "Tracer Test Error!")
+	assert.Equal(t, 1, r.count(0, rxgo.TraceError), "Tracer Test Error!")
+	assert.Equal(t, 4, r.count(1, rxgo.TraceIn), "Tracer Test Error!")
+	assert.Equal(t, 3, r.count(1, rxgo.TraceOut), "Tracer Test Error!")
+	assert.Equal(t, 4, r.count(2, rxgo.TraceIn), "Tracer Test Error!")
+	assert.Equal(t, 2, r.count(2, rxgo.TraceOut), "Tracer Test Error!")
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 1, r.count(i, rxgo.TraceComplete), "Tracer Test Error!")
+	}
+	for _, ev := range r.events {
+		assert.Equal(t, []string{"Just", "map", "filter"}[ev.Index], ev.Name, "Tracer Test Error!")
+	}
+}
+
+func TestTracerResubscribe(t *testing.T) {
+	var mu sync.Mutex
+	counts := map[string]int{}
+	ob := rxgo.Just(1, 2).Map(func(x int) int {
+		return x
+	}).SetTracer(rxgo.TracerFunc(func(ev rxgo.TraceEvent) {
+		mu.Lock()
+		counts[ev.Name]++
+		mu.Unlock()
+	})).Filter(func(x int) bool {
+		return true
+	})
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, []interface{}{1, 2}, collect(ob), "Tracer Resubscribe Test Error!")
+	}
+	// 2 in, 2 out and complete for each Observable and subscription
+	assert.Equal(t, map[string]int{"map": 10, "filter": 10}, counts, "Tracer Resubscribe Test Error!")
+}
+
+func TestTracerLatency(t *testing.T) {
+	s := rxgo.NewTestScheduler()
+	r := &traceRecorder{}
+	entered, release := make(chan struct{}), make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		rxgo.Just(1).SetScheduler(s).Map(func(x int) int {
+			close(entered)
+			<-release
+			return x
+		}).SetTracer(r).Subscribe(func(x int) {})
+		close(done)
+	}()
+
+	<-entered
+	s.Advance(5 * time.Millisecond)
+	close(release)
+	<-done
+	for _, ev := range r.events {
+		if ev.Kind == rxgo.TraceOut {
+			assert.Equal(t, 1, ev.Index, "Tracer Test Error!")
+			assert.Equal(t, 5*time.Millisecond, ev.Latency, "Tracer Test Error!")
+			assert.Equal(t, int(rxgo.BufferLen), ev.BufferCap, "Tracer Test Error!")
+		}
+	}
+	assert.Equal(t, 1, r.count(1, rxgo.TraceOut), "Tracer Test Error!")
+}
+
+func TestSlogTracer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	rxgo.Just(1).SetTracer(rxgo.NewSlogTracer(logger)).Subscribe(func(x int) {})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines), "SlogTracer Test Error!")
+	assert.Contains(t, lines[0], `"level":"DEBUG"`)
+	assert.Contains(t, lines[0], `"observable":"Just","index":0,"event":"out","item":1`)
+	assert.Contains(t, lines[1], `"level":"INFO"`)
+	assert.Contains(t, lines[1], `"event":"complete"`)
+}
+
+func TestMetricsTracer(t *testing.T) {
+	mt := rxgo.NewMetricsTracer()
+	rxgo.Range(0, 3).SetTracer(mt).Map(func(x int) int {
+		return x
+	}).Subscribe(func(x int) {})
+
+	w := httptest.NewRecorder()
+	mt.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "application/openmetrics-text; version=1.0.0; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	for _, line := range []string{
+		"# TYPE rxgo_items_in counter",
+		`rxgo_items_out_total{observable="Range",index="0"} 3`,
+		`rxgo_items_in_total{observable="map",index="1"} 3`,
+		`rxgo_items_out_total{observable="map",index="1"} 3`,
+		`rxgo_completed_total{observable="map",index="1"} 1`,
+		`rxgo_item_latency_seconds_count{observable="map",index="1"} 3`,
+		`rxgo_buffer_depth{observable="map",index="1"} 0`,
+	} {
+		assert.Contains(t, body, line+"\n", "MetricsTracer Test Error!")
+	}
+	assert.True(t, strings.HasSuffix(body, "# EOF\n"), "MetricsTracer Test Error!")
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rxgo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SlogTracer logs trace events to a slog.Logger. Items in and out are logged at debug level,
+// errors at warn level and completion at info level
+type SlogTracer struct {
+	Logger *slog.Logger
+}
+
+// NewSlogTracer creates a SlogTracer, the default logger is used if logger is nil
+func NewSlogTracer(logger *slog.Logger) SlogTracer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return SlogTracer{logger}
+}
+
+func (st SlogTracer) Trace(ev TraceEvent) {
+	level := slog.LevelDebug
+	switch ev.Kind {
+	case TraceError:
+		level = slog.LevelWarn
+	case TraceComplete:
+		level = slog.LevelInfo
+	}
+	attrs := []slog.Attr{
+		slog.String("observable", ev.Name),
+		slog.Int("index", ev.Index),
+		slog.String("event", ev.Kind.String()),
+	}
+	switch ev.Kind {
+	case TraceIn:
+		attrs = append(attrs, slog.Any("item", ev.Item), slog.Int("buffer", ev.BufferLen))
+	case TraceOut, TraceError:
+		attrs = append(attrs, slog.Any("item", ev.Item), slog.Duration("latency", ev.Latency), slog.Int("buffer", ev.BufferLen))
+	}
+	st.Logger.LogAttrs(context.Background(), level, "rxgo trace", attrs...)
+}
+
+// MetricsTracer counts trace events of each Observable, and exports them in the OpenMetrics text format.
+// It is an http.Handler serving the metrics
+type MetricsTracer struct {
+	mu      sync.Mutex
+	metrics map[metricsKey]*observableMetrics
+}
+
+type metricsKey struct {
+	index int
+	name  string
+}
+
+type observableMetrics struct {
+	in, out, errors, completed uint64
+	latencySum                 float64 // seconds
+	latencyCount               uint64
+	buffer                     int
+}
+
+// NewMetricsTracer creates a MetricsTracer
+func NewMetricsTracer() *MetricsTracer {
+	return &MetricsTracer{metrics: make(map[metricsKey]*observableMetrics)}
+}
+
+func (mt *MetricsTracer) Trace(ev TraceEvent) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+	key := metricsKey{ev.Index, ev.Name}
+	m, ok := mt.metrics[key]
+	if !ok {
+		m = &observableMetrics{}
+		mt.metrics[key] = m
+	}
+	switch ev.Kind {
+	case TraceIn:
+		m.in++
+		return
+	case TraceOut:
+		m.out++
+	case TraceError:
+		m.errors++
+	case TraceComplete:
+		m.completed++
+		m.buffer = 0
+		return
+	}
+	m.latencySum += ev.Latency.Seconds()
+	m.latencyCount++
+	m.buffer = ev.BufferLen
+}
+
+// WriteTo writes the metrics in the OpenMetrics text format
+func (mt *MetricsTracer) WriteTo(w io.Writer) (n int64, err error) {
+	mt.mu.Lock()
+	keys := make([]metricsKey, 0, len(mt.metrics))
+	snapshot := make(map[metricsKey]observableMetrics)
+	for k, m := range mt.metrics {
+		keys = append(keys, k)
+		snapshot[k] = *m
+	}
+	mt.mu.Unlock()
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].index != keys[j].index {
+			return keys[i].index < keys[j].index
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	var b strings.Builder
+	family := func(name, typ, help string, sample func(labels string, m observableMetrics)) {
+		fmt.Fprintf(&b, "# TYPE %s %s\n# HELP %s %s\n", name, typ, name, help)
+		for _, k := range keys {
+			sample(fmt.Sprintf(`{observable="%s",index="%d"}`, escapeLabel(k.name), k.index), snapshot[k])
+		}
+	}
+	counter := func(name, help string, value func(m observableMetrics) uint64) {
+		family(name, "counter", help, func(labels string, m observableMetrics) {
+			fmt.Fprintf(&b, "%s_total%s %d\n", name, labels, value(m))
+		})
+	}
+	counter("rxgo_items_in", "Items flowed into the Observable.", func(m observableMetrics) uint64 { return m.in })
+	counter("rxgo_items_out", "Items flowed out of the Observable.", func(m observableMetrics) uint64 { return m.out })
+	counter("rxgo_errors", "Errors flowed out of the Observable.", func(m observableMetrics) uint64 { return m.errors })
+	counter("rxgo_completed", "Completed flows of the Observable.", func(m observableMetrics) uint64 { return m.completed })
+	family("rxgo_item_latency_seconds", "summary", "Time since the latest item flowed in when an item flows out.", func(labels string, m observableMetrics) {
+		fmt.Fprintf(&b, "rxgo_item_latency_seconds_sum%s %g\n", labels, m.latencySum)
+		fmt.Fprintf(&b, "rxgo_item_latency_seconds_count%s %d\n", labels, m.latencyCount)
+	})
+	family("rxgo_buffer_depth", "gauge", "Items in the outflow of the Observable.", func(labels string, m observableMetrics) {
+		fmt.Fprintf(&b, "rxgo_buffer_depth%s %d\n", labels, m.buffer)
+	})
+	b.WriteString("# EOF\n")
+
+	c, err := io.WriteString(w, b.String())
+	return int64(c), err
+}
+
+// ServeHTTP serves the metrics in the OpenMetrics text format
+func (mt *MetricsTracer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	mt.WriteTo(w)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
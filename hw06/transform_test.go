@@ -3,7 +3,12 @@ package rxgo_test
 import (
 	"context"
 	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pmlpml/rxgo"
 	"github.com/stretchr/testify/assert"
@@ -97,3 +102,66 @@ func TestFilter(t *testing.T) {
 
 	assert.Equal(t, []int{0, 7, 2}, res, "Map Test Error!")
 }
+
+func TestComputingPool(t *testing.T) {
+	var running, most int32
+	res := []int{}
+	rxgo.Range(0, 50).Map(func(x int) int {
+		n := atomic.AddInt32(&running, 1)
+		for m := atomic.LoadInt32(&most); n > m && !atomic.CompareAndSwapInt32(&most, m, n); m = atomic.LoadInt32(&most) {
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return x
+	}).SubscribeOn(rxgo.ThreadingComputing).SetPoolSize(3).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+
+	sort.Ints(res)
+	assert.Equal(t, 50, len(res), "Computing pool lost items")
+	assert.Equal(t, 0, res[0], "Computing pool Test Error!")
+	assert.Equal(t, 49, res[49], "Computing pool Test Error!")
+	assert.True(t, most <= 3, "Computing pool runs too many goroutines")
+}
+
+func TestComputingPoolOrdered(t *testing.T) {
+	expected := []int{}
+	for i := 0; i < 50; i++ {
+		expected = append(expected, i, i)
+	}
+
+	res := []int{}
+	rxgo.Range(0, 50).FlatMap(func(x int) *rxgo.Observable {
+		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
+		return rxgo.Just(x, x)
+	}).SubscribeOn(rxgo.ThreadingComputing).SetPoolSize(4).SetOrdered(true).Subscribe(func(x int) {
+		res = append(res, x)
+	})
+	assert.Equal(t, expected, res, "Ordered computing pool Test Error!")
+}
+
+func TestComputingPoolOrderedBackpressure(t *testing.T) {
+	gate := make(chan struct{})
+	var once sync.Once
+	ob := rxgo.Range(0, 20).Map(func(x int) int {
+		return x
+	}).SubscribeOn(rxgo.ThreadingComputing).SetOrdered(true).SetBufferLen(1).SetBackpressure(rxgo.BackpressureDropNewest)
+	ob.SetMonitor(rxgo.ObserverMonitor{
+		Dropped: func(x interface{}, n uint64) {
+			once.Do(func() {
+				close(gate)
+			})
+		},
+	})
+
+	res := []int{}
+	ob.Subscribe(func(x int) {
+		if len(res) == 0 {
+			<-gate
+		}
+		res = append(res, x)
+	})
+	assert.True(t, ob.Dropped() > 0, "Ordered computing pool should drop items by backpressure")
+	assert.Equal(t, 20, len(res)+int(ob.Dropped()), "Ordered computing pool lost items")
+	assert.True(t, sort.IntsAreSorted(res), "Ordered computing pool Test Error!")
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 var (
@@ -26,15 +27,24 @@ type transOperater struct {
 func (tsop transOperater) op(ctx context.Context, o *Observable) {
 	// must hold defintion of flow resourcs here, such as chan etc., that is allocated when connected
 	// this resurces may be changed when operation routine is running.
-	in := o.pred.outflow
+	in := o.inflow
 	out := o.outflow
 	//fmt.Println(o.name, "operator in/out chan ", in, out)
 	var wg sync.WaitGroup
+	var pool *workerPool
+	if o.threading == ThreadingComputing {
+		pool = o.newWorkerPool(ctx, out)
+	}
 
 	go func() {
-		end := false
+		var end int32 // set by goroutines serving items
+		// the upstream is stopped, the items left are drained
+		stop := func() {
+			atomic.StoreInt32(&end, 1)
+			cancelUpstream(ctx)
+		}
 		for x := range in {
-			if end {
+			if atomic.LoadInt32(&end) == 1 {
 				continue
 			}
 			// can not pass a interface as parameter (pointer) to gorountion for it may change its value outside!
@@ -48,23 +58,32 @@ func (tsop transOperater) op(ctx context.Context, o *Observable) {
 			switch threading := o.threading; threading {
 			case ThreadingDefault:
 				if tsop.opFunc(ctx, o, xv, out) {
-					end = true
+					stop()
 				}
 			case ThreadingIO:
-				fallthrough
-			case ThreadingComputing:
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
 					if tsop.opFunc(ctx, o, xv, out) {
-						end = true
+						stop()
 					}
 				}()
+			case ThreadingComputing:
+				pool.submit(func(ctx context.Context, out chan interface{}) bool {
+					if tsop.opFunc(ctx, o, xv, out) {
+						stop()
+						return true
+					}
+					return false
+				})
 			default:
 			}
 		}
 
 		wg.Wait() //waiting all go-routines completed
+		if pool != nil {
+			pool.wait()
+		}
 		o.closeFlow(out)
 	}()
 }
@@ -85,8 +104,18 @@ var transformOperater = transOperater{func(ctx context.Context, o *Observable, x
 		endSignal = o.sendToFlow(ctx, x, out)
 		return
 	}
-	tf(ctx, x.Interface(), send)
-	return
+	// a nil item has no valid reflect.Value
+	var item interface{}
+	if x.IsValid() {
+		item = x.Interface()
+	}
+	_, stop, e := o.userFuncDo(item, func() {
+		tf(ctx, item, send)
+	})
+	if e != nil && o.sendToFlow(ctx, e, out) {
+		return true
+	}
+	return stop
 }}
 
 // Map maps each item in Observable by the function with `func(x anytype) anytype` and
@@ -112,20 +141,21 @@ func (parent *Observable) Map(f interface{}) (o *Observable) {
 
 var mapOperater = transOperater{func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
 
-	fv := reflect.ValueOf(o.flip)
-	var params = []reflect.Value{x}
-	rs, skip, stop, e := userFuncCall(fv, params)
+	rs, skip, stop, e := o.callFlip(ctx, x)
 
-	var item interface{} = rs[0].Interface()
 	if stop {
+		if e != nil {
+			o.sendToFlow(ctx, e, out)
+		}
 		end = true
 		return
 	}
 	if skip {
 		return
 	}
-	if e != nil {
-		item = e
+	var item interface{} = e
+	if e == nil {
+		item = rs[0].Interface()
 	}
 	// send data
 	if !end {
@@ -137,6 +167,7 @@ var mapOperater = transOperater{func(ctx context.Context, o *Observable, x refle
 
 // FlatMap maps each item in Observable by the function with `func(x anytype) (o *Observable) ` and
 // returns a new Observable with merged observables appling on each items.
+// See ConcatMap, SwitchMap, ExhaustMap and FlatMapWithConcurrency for other flattening strategies.
 func (parent *Observable) FlatMap(f interface{}) (o *Observable) {
 	// check validation of f
 	fv := reflect.ValueOf(f)
@@ -158,14 +189,12 @@ func (parent *Observable) FlatMap(f interface{}) (o *Observable) {
 
 var flatMapOperater = transOperater{func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
 
-	fv := reflect.ValueOf(o.flip)
-	var params = []reflect.Value{x}
-	//fmt.Println("x is ", x)
-	rs, skip, stop, e := userFuncCall(fv, params)
-
-	var item = rs[0].Interface().(*Observable)
+	rs, skip, stop, e := o.callFlip(ctx, x)
 
 	if stop {
+		if e != nil {
+			o.sendToFlow(ctx, e, out)
+		}
 		end = true
 		return
 	}
@@ -181,12 +210,13 @@ var flatMapOperater = transOperater{func(ctx context.Context, o *Observable, x r
 	}
 	// send data
 	if !end {
-		if item != nil {
+		if item, _ := rs[0].Interface().(*Observable); item != nil {
 			// subscribe ro without any ObserveOn model
 			ro := item
 			for ; ro.next != nil; ro = ro.next {
 			}
-			ro.connect(ctx)
+			release := ro.connect(ctx)
+			defer release()
 
 			ch := ro.outflow
 			for x := range ch {
@@ -223,12 +253,12 @@ func (parent *Observable) Filter(f interface{}) (o *Observable) {
 
 var filterOperater = transOperater{func(ctx context.Context, o *Observable, x reflect.Value, out chan interface{}) (end bool) {
 
-	fv := reflect.ValueOf(o.flip)
-	var params = []reflect.Value{x}
-	rs, skip, stop, e := userFuncCall(fv, params)
+	rs, skip, stop, e := o.callFlip(ctx, x)
 
-	var item interface{} = rs[0].Interface()
 	if stop {
+		if e != nil {
+			o.sendToFlow(ctx, e, out)
+		}
 		end = true
 		return
 	}
@@ -236,11 +266,12 @@ var filterOperater = transOperater{func(ctx context.Context, o *Observable, x re
 		return
 	}
 	if e != nil {
-		item = e
+		end = o.sendToFlow(ctx, e, out)
+		return
 	}
 	// send data
 	if !end {
-		if b, ok := item.(bool); ok && b {
+		if b, ok := rs[0].Interface().(bool); ok && b {
 			end = o.sendToFlow(ctx, x.Interface(), out)
 		}
 	}
@@ -0,0 +1,125 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typed
+
+import (
+	"context"
+	"iter"
+
+	"github.com/pmlpml/rxgo"
+)
+
+// First or Last gets nothing from an empty Observable
+var ErrNoItem = rxgo.ErrNoItem
+
+// run subscribes the Observable and calls f with each item or error until f returns true.
+// The Observable is stopped then. It returns the error of ctx if ctx is done before the Observable completes
+func (o *Observable[T]) run(ctx context.Context, f func(x T, e error) (stop bool)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stopped := false
+	call := func(x T, e error) {
+		if !stopped && f(x, e) {
+			stopped = true
+			cancel()
+		}
+	}
+	o.SubscribeWith(ctx, Observer[T]{
+		Next: func(x T) {
+			call(x, nil)
+		},
+		Error: func(e error) {
+			var zero T
+			call(zero, e)
+		},
+	})
+	if stopped {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// ToSlice returns all the items when the Observable completes.
+// It stops at the first error, and returns the items before it with the error
+func (o *Observable[T]) ToSlice(ctx context.Context) (items []T, err error) {
+	items = []T{}
+	if e := o.run(ctx, func(x T, e error) bool {
+		if e != nil {
+			err = e
+			return true
+		}
+		items = append(items, x)
+		return false
+	}); e != nil {
+		err = e
+	}
+	return
+}
+
+// First returns the first item and stops the Observable, or the first error if it comes before any item.
+// It returns ErrNoItem if the Observable completes without any item
+func (o *Observable[T]) First(ctx context.Context) (first T, err error) {
+	err = ErrNoItem
+	if e := o.run(ctx, func(x T, e error) bool {
+		first, err = x, e
+		return true
+	}); e != nil {
+		err = e
+	}
+	return
+}
+
+// Last returns the last item when the Observable completes. It stops at the first error and returns it.
+// It returns ErrNoItem if the Observable completes without any item
+func (o *Observable[T]) Last(ctx context.Context) (last T, err error) {
+	err = ErrNoItem
+	if e := o.run(ctx, func(x T, e error) bool {
+		if e != nil {
+			var zero T
+			last, err = zero, e
+			return true
+		}
+		last, err = x, nil
+		return false
+	}); e != nil {
+		err = e
+	}
+	return
+}
+
+// ForEach calls f with each item until the Observable completes. It stops at the first error
+// emitted by the Observable or returned by f, and returns it
+func (o *Observable[T]) ForEach(ctx context.Context, f func(x T) error) (err error) {
+	if e := o.run(ctx, func(x T, e error) bool {
+		if e == nil {
+			e = f(x)
+		}
+		err = e
+		return e != nil
+	}); e != nil {
+		err = e
+	}
+	return
+}
+
+// Values returns an iterator over the items, errors are skipped. Breaking the loop stops the Observable
+func (o *Observable[T]) Values(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		o.run(ctx, func(x T, e error) bool {
+			return e == nil && !yield(x)
+		})
+	}
+}
+
+// All returns an iterator over the items and errors, an error is yielded with the zero value of T.
+// Breaking the loop stops the Observable
+func (o *Observable[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		o.run(ctx, func(x T, e error) bool {
+			return !yield(x, e)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/pmlpml/rxgo/typed"
+	"github.com/stretchr/testify/assert"
+)
+
+// an endless Observable of 0, 1, 2 ...
+func naturals() *typed.Observable[int] {
+	return typed.Of[int](rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		for i := 0; !send(i); i++ {
+		}
+	}))
+}
+
+func TestToSlice(t *testing.T) {
+	ctx := context.Background()
+	res, err := typed.Just(1, 2, 3).ToSlice(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, res, "ToSlice Test Error!")
+
+	e := errors.New("any")
+	res, err = typed.Of[int](rxgo.Just(1, e, 2)).ToSlice(ctx)
+	assert.Equal(t, e, err, "ToSlice should stop at error")
+	assert.Equal(t, []int{1}, res, "ToSlice Test Error!")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = naturals().ToSlice(canceled)
+	assert.Equal(t, context.Canceled, err, "ToSlice should stop when ctx is done")
+}
+
+func TestFirstLast(t *testing.T) {
+	ctx := context.Background()
+	x, err := naturals().First(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, x, "First Test Error!")
+
+	x, err = typed.Just(1, 2, 3).Last(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, x, "Last Test Error!")
+
+	_, err = typed.Just[int]().First(ctx)
+	assert.Equal(t, typed.ErrNoItem, err, "First Test Error!")
+	_, err = typed.Just[int]().Last(ctx)
+	assert.Equal(t, typed.ErrNoItem, err, "Last Test Error!")
+}
+
+func TestForEach(t *testing.T) {
+	stop := errors.New("stop")
+	sum := 0
+	err := naturals().ForEach(context.Background(), func(x int) error {
+		if x > 4 {
+			return stop
+		}
+		sum += x
+		return nil
+	})
+	assert.Equal(t, stop, err, "ForEach Test Error!")
+	assert.Equal(t, 10, sum, "ForEach Test Error!")
+}
+
+func TestIterators(t *testing.T) {
+	ctx := context.Background()
+	res := []int{}
+	for x := range naturals().Values(ctx) {
+		if x == 3 {
+			break
+		}
+		res = append(res, x)
+	}
+	assert.Equal(t, []int{0, 1, 2}, res, "Values Test Error!")
+
+	e := errors.New("any")
+	res, errs := []int{}, []error{}
+	for x, err := range typed.Of[int](rxgo.Just(1, e, 2)).All(ctx) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		res = append(res, x)
+	}
+	assert.Equal(t, []int{1, 2}, res, "All Test Error!")
+	assert.Equal(t, []error{e}, errs, "All Test Error!")
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package typed is a type-safe API of rxgo with generics. An Observable[T] is an rxgo Observable
+// whose items are T or errors, so that user functions are checked at compile time and called without reflection.
+// It runs on the same operators, schedulers and channels, and is converted to or from an untyped Observable by Of and Untyped
+package typed
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pmlpml/rxgo"
+)
+
+// an item of an untyped Observable is not T, it flows as FlowableError.Elements
+var ErrItemType = errors.New("Item type error!")
+
+// An Observable[T] emits items of type T and errors
+type Observable[T any] struct {
+	ob *rxgo.Observable
+}
+
+// Observer receives items, errors and completion of an Observable[T]. The functions may be nil
+type Observer[T any] struct {
+	Next      func(x T)
+	Error     func(e error)
+	Completed func()
+}
+
+// Of converts an untyped Observable to Observable[T]. The items which are not T flow as FlowableError with ErrItemType
+func Of[T any](ob *rxgo.Observable) *Observable[T] {
+	return &Observable[T]{ob.TransformOp(func(ctx context.Context, item interface{}, send func(x interface{}) (endSignal bool)) {
+		switch item.(type) {
+		case error, T:
+			send(item)
+		default:
+			send(rxgo.FlowableError{Err: ErrItemType, Elements: item})
+		}
+	})}
+}
+
+// itemOf returns the item as T, a nil item is the zero value of T
+func itemOf[T any](x interface{}) T {
+	v, _ := x.(T)
+	return v
+}
+
+// Untyped returns the untyped Observable to chain operators of rxgo, or to set the scheduler, threading etc.
+func (o *Observable[T]) Untyped() *rxgo.Observable {
+	return o.ob
+}
+
+// Just creates an Observable[T] with the provided items
+func Just[T any](items ...T) *Observable[T] {
+	return From(items)
+}
+
+// From creates an Observable[T] emitting the items of a slice
+func From[T any](items []T) *Observable[T] {
+	return &Observable[T]{rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		for _, x := range items {
+			if send(x) {
+				return
+			}
+		}
+	})}
+}
+
+// FromChan creates an Observable[T] emitting the items received from a channel until it is closed
+func FromChan[T any](ch <-chan T) *Observable[T] {
+	return &Observable[T]{rxgo.Generator(func(ctx context.Context, send func(x interface{}) (endSignal bool)) {
+		for {
+			select {
+			case x, ok := <-ch:
+				if !ok || send(x) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})}
+}
+
+// Subscribe runs the Observable[T] and calls next with each item, errors are skipped. It blocks until the Observable completes
+func (o *Observable[T]) Subscribe(next func(x T)) {
+	o.SubscribeWith(context.Background(), Observer[T]{Next: next})
+}
+
+// SubscribeWith runs the Observable[T] with the observer. It blocks until the Observable completes,
+// or the context is done which stops the Observable
+func (o *Observable[T]) SubscribeWith(ctx context.Context, ob Observer[T]) {
+	o.ob.Subscribe(rxgo.ObserverMonitor{
+		Next: func(x interface{}) {
+			if ob.Next != nil {
+				ob.Next(itemOf[T](x))
+			}
+		},
+		Error:     ob.Error,
+		Completed: ob.Completed,
+		Context: func() context.Context {
+			return ctx
+		},
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 The SS.SYSU Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typed
+
+import (
+	"context"
+)
+
+// Map maps each item of type T by f, errors flow as they are
+func Map[T, U any](o *Observable[T], f func(x T) U) *Observable[U] {
+	return &Observable[U]{o.ob.TransformOp(func(ctx context.Context, item interface{}, send func(x interface{}) (endSignal bool)) {
+		if e, ok := item.(error); ok {
+			send(e)
+			return
+		}
+		send(f(itemOf[T](item)))
+	})}
+}
+
+// Filter emits the items which satisfy pred, errors flow as they are
+func Filter[T any](o *Observable[T], pred func(x T) bool) *Observable[T] {
+	return &Observable[T]{o.ob.TransformOp(func(ctx context.Context, item interface{}, send func(x interface{}) (endSignal bool)) {
+		if _, ok := item.(error); !ok && !pred(itemOf[T](item)) {
+			return
+		}
+		send(item)
+	})}
+}
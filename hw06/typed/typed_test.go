@@ -0,0 +1,107 @@
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pmlpml/rxgo"
+	"github.com/pmlpml/rxgo/typed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapFilter(t *testing.T) {
+	res := []string{}
+	even := typed.Filter(typed.Just(1, 2, 3, 4), func(x int) bool {
+		return x%2 == 0
+	})
+	typed.Map(even, strconv.Itoa).Subscribe(func(x string) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []string{"2", "4"}, res, "Map Test Error!")
+}
+
+func TestMapPanic(t *testing.T) {
+	res, errs := []int{}, []error{}
+	tenfold := typed.Map(typed.Just(1, 2, 3), func(x int) int {
+		if x == 2 {
+			panic("boom")
+		}
+		return x * 10
+	})
+	for x, e := range tenfold.All(context.Background()) {
+		if e != nil {
+			errs = append(errs, e)
+			continue
+		}
+		res = append(res, x)
+	}
+	assert.Equal(t, []int{10, 30}, res, "Map Panic Test Error!")
+	assert.Equal(t, 1, len(errs), "Map Panic Test Error!")
+	assert.True(t, errors.Is(errs[0], rxgo.ErrPanic), "Map Panic Test Error!")
+	assert.Equal(t, 2, errs[0].(rxgo.FlowableError).Elements, "Map Panic Test Error!")
+}
+
+func TestNilItems(t *testing.T) {
+	var stringers []fmt.Stringer
+	typed.Just[fmt.Stringer](nil, time.Second).Subscribe(func(x fmt.Stringer) {
+		stringers = append(stringers, x)
+	})
+	assert.Equal(t, []fmt.Stringer{nil, time.Second}, stringers, "Subscribe Nil Test Error!")
+
+	names := []string{}
+	err := typed.Map(typed.Just[fmt.Stringer](nil), func(x fmt.Stringer) string {
+		if x == nil {
+			return "nil"
+		}
+		return x.String()
+	}).ForEach(context.Background(), func(x string) error {
+		names = append(names, x)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nil"}, names, "Map Nil Test Error!")
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan float64, 3)
+	ch <- 0.5
+	ch <- 1.5
+	close(ch)
+	res := []float64{}
+	typed.FromChan(ch).Subscribe(func(x float64) {
+		res = append(res, x)
+	})
+	assert.Equal(t, []float64{0.5, 1.5}, res, "FromChan Test Error!")
+}
+
+func TestAdapters(t *testing.T) {
+	e := errors.New("any")
+	var errs []error
+	res := []int{}
+	o := typed.Of[int](rxgo.Just(1, "x", e, 2))
+	typed.Map(o, func(x int) int {
+		return x * 10
+	}).SubscribeWith(context.Background(), typed.Observer[int]{
+		Next: func(x int) {
+			res = append(res, x)
+		},
+		Error: func(e error) {
+			errs = append(errs, e)
+		},
+	})
+	assert.Equal(t, []int{10, 20}, res, "Of Test Error!")
+	assert.Equal(t, []error{rxgo.FlowableError{Err: typed.ErrItemType, Elements: "x"}, e}, errs, "Of Test Error!")
+
+	// back to the untyped chain
+	sum := 0
+	typed.Just(1, 2, 3).Untyped().Reduce(func(a, b int) int {
+		return a + b
+	}).Subscribe(func(x int) {
+		sum = x
+	})
+	assert.Equal(t, 6, sum, "Untyped Test Error!")
+}
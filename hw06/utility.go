@@ -5,8 +5,10 @@
 package rxgo
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"runtime/debug"
 )
 
 // Test Observer
@@ -118,27 +120,51 @@ func checkFuncAcceptError(fv reflect.Value) (b bool) {
 	return
 }
 
-// wrap exception when call user function
+// wrap exception when call user function, other panics are recovered into FlowableError with ErrPanic
 func userFuncCall(fv reflect.Value, params []reflect.Value) (res []reflect.Value, skip, stop bool, eout error) {
 	defer func() {
 		if e := recover(); e != nil {
-			if fe, ok := e.(FlowableError); ok {
-				eout = fe
-				return
-			}
-			switch e {
-			case ErrSkipItem:
-				skip = true
-				return
-			case ErrEoFlow:
-				stop = true
-				return
-			default:
-				panic(e)
-			}
+			skip, stop, eout = userPanic(e, funcItems(params))
 		}
 	}()
 
 	res = fv.Call(params)
 	return
 }
+
+// the result of a recovered panic of a user function called with items, ErrSkipItem and ErrEoFlow are signals
+func userPanic(e interface{}, items interface{}) (skip, stop bool, eout error) {
+	if fe, ok := e.(FlowableError); ok {
+		return false, false, fe
+	}
+	switch e {
+	case ErrSkipItem:
+		skip = true
+	case ErrEoFlow:
+		stop = true
+	default:
+		eout = FlowableError{
+			Err:      fmt.Errorf("%w: %v", ErrPanic, e),
+			Elements: items,
+			Stack:    string(debug.Stack()),
+		}
+	}
+	return
+}
+
+// the items passed to a user function without its context, a slice for more than one item
+func funcItems(params []reflect.Value) interface{} {
+	var items []interface{}
+	for _, p := range params {
+		if _, ok := p.Interface().(context.Context); !ok {
+			items = append(items, p.Interface())
+		}
+	}
+	switch len(items) {
+	case 0:
+		return nil
+	case 1:
+		return items[0]
+	}
+	return items
+}